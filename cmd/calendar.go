@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/spf13/cobra"
+)
+
+// calendarCmd represents the calendar command
+var calendarCmd = &cobra.Command{
+	Use:   "calendar [types...]",
+	Short: "Exports the upcoming draws as an iCalendar file",
+	Long: `Exports the upcoming draws as an iCalendar (RFC 5545) file that any calendar app can import.
+Every draw of the given games, or of every game with a draw schedule, becomes an event.
+With --remind, each event also reminds you that long before sales close.
+
+Each event is identified by its game and draw number,
+so importing a newer export again updates the same events.`,
+	Example: `  loto calendar --export draws.ics --weeks 8 loto6 loto7
+  loto calendar --export draws.ics --remind 2h`,
+	ValidArgsFunction: completeLotteryTypes,
+	PreRunE:           preRunCalendar,
+	RunE:              runCalendar,
+}
+
+type calendarOptions struct {
+	lotteryTypes []loto.LotteryType
+	weeks        int
+	remind       time.Duration
+	export       string
+}
+
+var calendarOpts calendarOptions
+
+func preRunCalendar(cmd *cobra.Command, args []string) error {
+	var err error
+	if calendarOpts.lotteryTypes, err = scheduledTypes(args); err != nil {
+		return err
+	}
+
+	// --weeks
+	calendarOpts.weeks, _ = cmd.Flags().GetInt("weeks")
+	if calendarOpts.weeks <= 0 {
+		return fmt.Errorf("--weeks must be positive, got %d", calendarOpts.weeks)
+	}
+
+	// --remind
+	calendarOpts.remind, _ = cmd.Flags().GetDuration("remind")
+	if calendarOpts.remind < 0 {
+		return fmt.Errorf("--remind must not be negative, got %s", calendarOpts.remind)
+	}
+
+	// --export
+	calendarOpts.export, _ = cmd.Flags().GetString("export")
+	return nil
+}
+
+func runCalendar(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	until := now.AddDate(0, 0, 7*calendarOpts.weeks)
+	calendar := loto.Calendar{
+		Draws:    make(map[loto.LotteryType][]loto.ScheduledDraw, len(calendarOpts.lotteryTypes)),
+		Reminder: calendarOpts.remind,
+		Created:  now,
+	}
+	for _, lotteryType := range calendarOpts.lotteryTypes {
+		calendar.Draws[lotteryType] = scheduleOf(lotteryType).Until(now, until)
+	}
+
+	if calendarOpts.export == "" {
+		if err := calendar.Encode(cmd.OutOrStdout()); err != nil {
+			return fmt.Errorf("failed to write the calendar: %w", err)
+		}
+		return nil
+	}
+
+	// The file is closed before the export is reported, so that a failed write is not reported as exported
+	f, err := os.Create(calendarOpts.export)
+	if err != nil {
+		return err
+	}
+	if err := calendar.Encode(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write the calendar: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write the calendar: %w", err)
+	}
+	count := 0
+	for _, draws := range calendar.Draws {
+		count += len(draws)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Exported %s to %s\n", loto.Plural(count, "draw"), calendarOpts.export)
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(calendarCmd)
+	calendarCmd.Flags().String("export", "", "File to write the calendar to (default stdout)")
+	calendarCmd.Flags().Int("weeks", 8, "Number of weeks of draws to export")
+	calendarCmd.Flags().Duration("remind", 0, "Remind this long before sales close (e.g. 2h), no reminder if 0")
+}
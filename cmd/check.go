@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/kawana77b/loto/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check [type]",
+	Short: "Checks tickets against the winning numbers",
+	Long: `Checks tickets against the winning numbers and displays the prize tier of each ticket.
+Numbers are given as comma-separated lists. The digits of numbers games may also be given without separators.
+Bingo numbers are given in the order of their positions, and the tiers are won by the complete lines of the card.`,
+	Example: `  loto check loto6 --ticket 1,5,12,23,34,41 --winning 1,5,12,23,34,40 --bonus 41
+  loto check numbers3 --bet box --ticket 123 --winning 321
+  loto check bingo5 --ticket 1,6,11,16,21,26,31,36 --winning 1,7,11,16,21,27,31,36`,
+	Args:              cobra.RangeArgs(0, 1),
+	ValidArgsFunction: completeLotteryTypes,
+	PreRunE:           preRunCheck,
+	RunE:              runCheck,
+}
+
+type checkOptions struct {
+	lotteryType loto.LotteryType
+	tickets     [][]int
+	draw        loto.Draw
+	bet         loto.BetType
+}
+
+var checkOpts checkOptions
+
+func preRunCheck(cmd *cobra.Command, args []string) error {
+	// lottery type
+	lotteryType, err := lotteryTypeFromArgs(args)
+	if err != nil {
+		return err
+	}
+	if err := rejectJumbo(cmd, lotteryType); err != nil {
+		return err
+	}
+	checkOpts.lotteryType = lotteryType
+	category := loto.GetCategory(lotteryType)
+
+	// --bet
+	bet, _ := cmd.Flags().GetString("bet")
+	checkOpts.bet = loto.BetType(bet)
+	if category == loto.NUMBERS && checkOpts.bet == "" {
+		checkOpts.bet = loto.STRAIGHT
+	}
+	if checkOpts.bet != "" {
+		config, _ := loto.Lookup(lotteryType)
+		if err := checkOpts.bet.Validate(config); err != nil {
+			return err
+		}
+	}
+
+	// --ticket
+	tickets, _ := cmd.Flags().GetStringArray("ticket")
+	checkOpts.tickets = make([][]int, 0, len(tickets))
+	for _, ticket := range tickets {
+		numbers, err := parseTicket(category, ticket)
+		if err != nil {
+			return fmt.Errorf("invalid ticket %q: %w", ticket, err)
+		}
+		checkOpts.tickets = append(checkOpts.tickets, numbers)
+	}
+
+	// --winning
+	winning, _ := cmd.Flags().GetString("winning")
+	numbers, err := parseTicket(category, winning)
+	if err != nil {
+		return fmt.Errorf("invalid winning numbers: %w", err)
+	}
+
+	// --bonus
+	bonusFlag, _ := cmd.Flags().GetString("bonus")
+	bonus, err := util.ParseInts(bonusFlag)
+	if err != nil {
+		return fmt.Errorf("invalid bonus numbers: %w", err)
+	}
+
+	checkOpts.draw = loto.Draw{
+		Numbers: numbers,
+		Bonus:   bonus,
+	}
+	return nil
+}
+
+// parseTicket parses the numbers of a ticket.
+// The digits of numbers games may also be written without separators (e.g., "033").
+func parseTicket(category loto.LotteryCategory, s string) ([]int, error) {
+	if category == loto.NUMBERS && !strings.Contains(s, ",") {
+		return util.ParseDigits(s)
+	}
+	return util.ParseInts(s)
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	category := loto.GetCategory(checkOpts.lotteryType)
+	if category == loto.NUMBERS {
+		return runCheckNumbers(cmd, category)
+	}
+
+	isBingo := category == loto.BINGO
+	data := &loto.Dataset{
+		Columns: []loto.Column{
+			{Key: "no", Title: "No", Index: true},
+			{Key: "ticket", Title: "Ticket"},
+		},
+	}
+	if isBingo {
+		data.Columns = append(data.Columns,
+			loto.Column{Key: "matches", Title: "Matches"},
+			loto.Column{Key: "lines", Title: "Lines"},
+		)
+	} else {
+		data.Columns = append(data.Columns,
+			loto.Column{Key: "main", Title: "Main"},
+			loto.Column{Key: "bonus", Title: "Bonus"},
+		)
+	}
+	data.Columns = append(data.Columns, loto.Column{Key: "prize", Title: "Prize"})
+
+	for i, ticket := range checkOpts.tickets {
+		result, err := loto.Check(checkOpts.lotteryType, ticket, checkOpts.draw)
+		if err != nil {
+			return err
+		}
+		if category == loto.LOTO {
+			slices.Sort(ticket)
+		}
+
+		var prize any
+		if result.Won() {
+			prize = result.Tier.Name()
+		}
+		row := []any{i + 1, loto.NewNumbers(category, ticket), result.Main}
+		if isBingo {
+			row = append(row, result.Lines)
+		} else {
+			row = append(row, result.Bonus)
+		}
+		data.Append(append(row, prize)...)
+	}
+	return render(cmd, data)
+}
+
+// runCheckNumbers checks numbers tickets for the bet type.
+func runCheckNumbers(cmd *cobra.Command, category loto.LotteryCategory) error {
+	data := &loto.Dataset{
+		Columns: []loto.Column{
+			{Key: "no", Title: "No", Index: true},
+			{Key: "ticket", Title: "Ticket"},
+			{Key: "bet", Title: "Bet"},
+			{Key: "box_type", Title: "Box Type"},
+			{Key: "prize", Title: "Prize"},
+		},
+	}
+
+	for i, ticket := range checkOpts.tickets {
+		result, err := loto.CheckNumbers(checkOpts.lotteryType, checkOpts.bet, ticket, checkOpts.draw.Numbers)
+		if err != nil {
+			return err
+		}
+
+		var boxType, prize any
+		if result.Bet != loto.MINI {
+			boxType = result.BoxType
+		}
+		if result.Won() {
+			prize = result.Prize
+		}
+		data.Append(
+			i+1,
+			loto.NewNumbers(category, ticket),
+			result.Bet,
+			boxType,
+			prize,
+		)
+	}
+	return render(cmd, data)
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	checkCmd.Flags().StringArrayP("ticket", "t", nil, "Ticket numbers to check (can be repeated)")
+	checkCmd.Flags().StringP("winning", "w", "", "Winning main numbers of the draw")
+	checkCmd.Flags().StringP("bonus", "b", "", "Winning bonus numbers of the draw")
+	checkCmd.Flags().String("bet", "", "Bet type for numbers games: straight, box, set or mini (default straight)")
+	checkCmd.MarkFlagRequired("ticket")
+	checkCmd.MarkFlagRequired("winning")
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/spf13/cobra"
+)
+
+// drawCmd represents the draw command
+var drawCmd = &cobra.Command{
+	Use:   "draw [type]",
+	Short: "Runs a simulated official draw",
+	Long: `Runs a simulated official draw.
+The main numbers and the bonus numbers are drawn from the same box without replacement.`,
+	Args:              cobra.RangeArgs(0, 1),
+	ValidArgsFunction: completeLotteryTypes,
+	PreRunE:           preRunDraw,
+	RunE:              runDraw,
+}
+
+type drawOptions struct {
+	lotteryType loto.LotteryType
+}
+
+var drawOpts drawOptions
+
+func preRunDraw(cmd *cobra.Command, args []string) error {
+	lotteryType, err := lotteryTypeFromArgs(args)
+	if err != nil {
+		return err
+	}
+	if err := rejectJumbo(cmd, lotteryType); err != nil {
+		return err
+	}
+	drawOpts.lotteryType = lotteryType
+	return nil
+}
+
+func runDraw(cmd *cobra.Command, args []string) error {
+	lottery, err := loto.NewLottery(drawOpts.lotteryType, gameOptions(cmd)...)
+	if err != nil {
+		return err
+	}
+
+	draw := lottery.Draw()
+	category := loto.GetCategory(drawOpts.lotteryType)
+
+	data := &loto.Dataset{
+		Columns: []loto.Column{
+			{Key: "numbers", Title: "Numbers"},
+		},
+	}
+	if len(draw.Bonus) == 0 {
+		data.Append(loto.NewNumbers(category, draw.Numbers))
+	} else {
+		data.Columns = append(data.Columns, loto.Column{Key: "bonus", Title: "Bonus"})
+		data.Append(
+			loto.NewNumbers(category, draw.Numbers),
+			loto.NewNumbers(category, draw.Bonus),
+		)
+	}
+	return render(cmd, data)
+}
+
+func init() {
+	rootCmd.AddCommand(drawCmd)
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/kawana77b/loto/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Displays the history of generated tickets",
+	Long: `Displays the history of generated tickets.
+Tickets are recorded when the root command is run with --save.`,
+	Args:    cobra.NoArgs,
+	PreRunE: preRunHistory,
+	RunE:    runHistory,
+}
+
+// historyShowCmd represents the history show command
+var historyShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Displays the tickets of a history entry",
+	Long:  `Displays the tickets of a history entry.`,
+	Args:  cobra.ExactArgs(1),
+	RunE:  runHistoryShow,
+}
+
+// historyDeleteCmd represents the history delete command
+var historyDeleteCmd = &cobra.Command{
+	Use:     "delete <id>...",
+	Aliases: []string{"rm"},
+	Short:   "Deletes history entries",
+	Long:    `Deletes history entries.`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runHistoryDelete,
+}
+
+type historyOptions struct {
+	filter store.HistoryFilter
+}
+
+var historyOpts historyOptions
+
+func preRunHistory(cmd *cobra.Command, args []string) error {
+	// --type
+	lt, _ := cmd.Flags().GetString("type")
+	historyOpts.filter.Type = loto.LotteryType(lt)
+	if historyOpts.filter.Type != "" {
+		if err := historyOpts.filter.Type.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// --since, --until
+	for name, date := range map[string]*store.Date{
+		"since": &historyOpts.filter.Since,
+		"until": &historyOpts.filter.Until,
+	} {
+		value, _ := cmd.Flags().GetString(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := store.ParseDate(value)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", name, err)
+		}
+		*date = parsed
+	}
+	return nil
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	history, err := store.DefaultHistoryStore()
+	if err != nil {
+		return err
+	}
+	entries, err := history.List(historyOpts.filter)
+	if err != nil {
+		return err
+	}
+
+	data := &loto.Dataset{
+		Columns: []loto.Column{
+			{Key: "id", Title: "ID"},
+			{Key: "date", Title: "Date"},
+			{Key: "type", Title: "Type"},
+			{Key: "draw", Title: "Draw"},
+			{Key: "seed", Title: "Seed"},
+			{Key: "tickets", Title: "Tickets"},
+		},
+	}
+	for _, entry := range entries {
+		data.Append(
+			entry.ID,
+			entry.CreatedAt.Local().Format(time.DateTime),
+			entry.Type,
+			drawCell(entry.Draw),
+			seedCell(entry.Seed),
+			len(entry.Tickets)+len(entry.Jumbo),
+		)
+	}
+	return render(cmd, data)
+}
+
+func runHistoryShow(cmd *cobra.Command, args []string) error {
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid history ID: %s", args[0])
+	}
+
+	history, err := store.DefaultHistoryStore()
+	if err != nil {
+		return err
+	}
+	entry, err := history.Get(id)
+	if err != nil {
+		return err
+	}
+
+	category := loto.GetCategory(entry.Type)
+	data := loto.PicksDataset(category, entry.Bet, entry.Tickets)
+	if category == loto.JUMBO {
+		data = loto.JumboDataset(entry.Jumbo)
+	}
+	data.Title = fmt.Sprintf("#%d %s %s", entry.ID, entry.Type, entry.CreatedAt.Local().Format(time.DateTime))
+	if entry.Draw > 0 {
+		data.Title += fmt.Sprintf(" for draw %d", entry.Draw)
+	}
+	if entry.Seed != nil {
+		data.Title += fmt.Sprintf(" (seed: %d)", *entry.Seed)
+	}
+	return render(cmd, data)
+}
+
+func runHistoryDelete(cmd *cobra.Command, args []string) error {
+	ids := make([]int, len(args))
+	for i, arg := range args {
+		id, err := strconv.Atoi(arg)
+		if err != nil {
+			return fmt.Errorf("invalid history ID: %s", arg)
+		}
+		ids[i] = id
+	}
+
+	history, err := store.DefaultHistoryStore()
+	if err != nil {
+		return err
+	}
+	if err := history.Delete(ids...); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Deleted history entries: %s\n", strings.Join(args, ", "))
+	return nil
+}
+
+// seedCell returns a recorded seed as a dataset cell (nil if not seeded).
+func seedCell(seed *uint64) any {
+	if seed == nil {
+		return nil
+	}
+	return *seed
+}
+
+// drawCell returns a recorded draw number as a dataset cell (nil if unknown).
+func drawCell(draw int) any {
+	if draw <= 0 {
+		return nil
+	}
+	return draw
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.AddCommand(historyShowCmd)
+	historyCmd.AddCommand(historyDeleteCmd)
+
+	historyCmd.Flags().StringP("type", "t", "", "Only display entries of the lottery type")
+	historyCmd.Flags().String("since", "", "Only display entries on or after the date (YYYY-MM-DD)")
+	historyCmd.Flags().String("until", "", "Only display entries on or before the date (YYYY-MM-DD)")
+}
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"os"
-
 	"github.com/kawana77b/loto/internal/loto"
 	"github.com/spf13/cobra"
 )
@@ -12,18 +10,21 @@ var listCmd = &cobra.Command{
 	Use:     "list",
 	Aliases: []string{"ls"},
 	Short:   "Displays the available argument names",
-	Long:    `Displays the available argument names.`,
-	RunE:    runList,
+	Long: `Displays the available argument names.
+With --odds, it also shows the odds of matching every main number (every digit in order for numbers games).`,
+	RunE: runList,
 }
 
 func runList(cmd *cobra.Command, args []string) error {
-	table, err := loto.Table(os.Stdout)
+	odds, _ := cmd.Flags().GetBool("odds")
+	data, err := loto.ConfigsDataset(odds)
 	if err != nil {
 		return err
 	}
-	return table.Render()
+	return render(cmd, data)
 }
 
 func init() {
 	rootCmd.AddCommand(listCmd)
+	listCmd.Flags().Bool("odds", false, "Show the odds of the jackpot of each game")
 }
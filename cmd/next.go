@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/kawana77b/loto/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// nextCmd represents the next command
+var nextCmd = &cobra.Command{
+	Use:   "next [type]",
+	Short: "Displays the next draws and their draw numbers",
+	Long: `Displays the next draws and their draw numbers (回号), in JST.
+Without a type, the next draws of every game with a draw schedule are displayed.
+No draw is held during the year-end break from December 31 to January 3.
+
+The draw numbers are counted from a reference draw of each game,
+or from the latest stored draw result (see loto results) if any.`,
+	Example: `  loto next
+  loto next loto6 -n 4`,
+	Args:              cobra.RangeArgs(0, 1),
+	ValidArgsFunction: completeLotteryTypes,
+	PreRunE:           preRunNext,
+	RunE:              runNext,
+}
+
+type nextOptions struct {
+	lotteryTypes []loto.LotteryType
+	length       int
+}
+
+var nextOpts nextOptions
+
+func preRunNext(cmd *cobra.Command, args []string) error {
+	var err error
+	if nextOpts.lotteryTypes, err = scheduledTypes(args); err != nil {
+		return err
+	}
+
+	// --length
+	nextOpts.length, _ = cmd.Flags().GetInt("length")
+	if nextOpts.length <= 0 {
+		return fmt.Errorf("--length must be positive, got %d", nextOpts.length)
+	}
+	return nil
+}
+
+func runNext(cmd *cobra.Command, args []string) error {
+	now := time.Now()
+	draws := make(map[loto.LotteryType][]loto.ScheduledDraw, len(nextOpts.lotteryTypes))
+	for _, lotteryType := range nextOpts.lotteryTypes {
+		draws[lotteryType] = scheduleOf(lotteryType).Next(now, nextOpts.length)
+	}
+	return render(cmd, loto.ScheduleDataset(draws))
+}
+
+// scheduledTypes returns the lottery types given as arguments, each of which must have a draw schedule.
+// If no argument is given, every lottery type with a draw schedule is returned.
+func scheduledTypes(args []string) ([]loto.LotteryType, error) {
+	var lotteryTypes []loto.LotteryType
+	if len(args) == 0 {
+		for _, name := range loto.Names() {
+			if config, _ := loto.Lookup(loto.LotteryType(name)); config.Schedule != nil {
+				lotteryTypes = append(lotteryTypes, loto.LotteryType(name))
+			}
+		}
+		return lotteryTypes, nil
+	}
+
+	for _, arg := range args {
+		lotteryType := loto.LotteryType(arg)
+		if err := lotteryType.Validate(); err != nil {
+			return nil, err
+		}
+		if config, _ := loto.Lookup(lotteryType); config.Schedule == nil {
+			return nil, fmt.Errorf("no draw schedule is defined for %s", lotteryType)
+		}
+		if !slices.Contains(lotteryTypes, lotteryType) {
+			lotteryTypes = append(lotteryTypes, lotteryType)
+		}
+	}
+	return lotteryTypes, nil
+}
+
+// scheduleOf returns the draw schedule of the lottery type, or nil if it has none.
+// If draw results of the lottery type are stored, the draw numbers are counted from the latest of them.
+func scheduleOf(lotteryType loto.LotteryType) *loto.Schedule {
+	config, _ := loto.Lookup(lotteryType)
+	if config.Schedule == nil {
+		return nil
+	}
+	schedule := *config.Schedule
+
+	results, err := store.DefaultResultStore()
+	if err != nil {
+		return &schedule
+	}
+	stored, err := results.Load(lotteryType)
+	if err != nil || len(stored) == 0 {
+		return &schedule
+	}
+	latest := slices.MaxFunc(stored, func(a, b store.DrawResult) int {
+		return a.Number - b.Number
+	})
+	withLatest := schedule
+	withLatest.Reference = loto.ScheduleReference{Number: latest.Number, Date: latest.Date.String()}
+	if withLatest.Validate() != nil {
+		// The latest result does not fit the schedule
+		return &schedule
+	}
+	return &withLatest
+}
+
+func init() {
+	rootCmd.AddCommand(nextCmd)
+	nextCmd.Flags().IntP("length", "n", 1, "Number of draws to display for each game")
+}
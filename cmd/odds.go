@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/spf13/cobra"
+)
+
+// oddsCmd represents the odds command
+var oddsCmd = &cobra.Command{
+	Use:   "odds [type]",
+	Short: "Displays the exact odds of every prize of a game",
+	Long: `Displays the exact odds of every prize of a game.
+Loto games show the matches of each prize tier and the odds of winning any prize.
+Numbers games show every bet type, or only --bet, and the box types of box and set tickets.`,
+	Example: `  loto odds loto6
+  loto odds numbers4 --bet box`,
+	Args:              cobra.RangeArgs(0, 1),
+	ValidArgsFunction: completeLotteryTypes,
+	PreRunE:           preRunOdds,
+	RunE:              runOdds,
+}
+
+type oddsOptions struct {
+	lotteryType loto.LotteryType
+	bet         loto.BetType
+}
+
+var oddsOpts oddsOptions
+
+func preRunOdds(cmd *cobra.Command, args []string) error {
+	lotteryType, err := lotteryTypeFromArgs(args)
+	if err != nil {
+		return err
+	}
+	if err := rejectJumbo(cmd, lotteryType); err != nil {
+		return err
+	}
+	oddsOpts.lotteryType = lotteryType
+
+	// --bet
+	bet, _ := cmd.Flags().GetString("bet")
+	oddsOpts.bet = loto.BetType(bet)
+	return nil
+}
+
+func runOdds(cmd *cobra.Command, args []string) error {
+	config, _ := loto.Lookup(oddsOpts.lotteryType)
+	odds, err := loto.ComputeOdds(config, oddsOpts.bet)
+	if err != nil {
+		return err
+	}
+	return render(cmd, loto.OddsDataset(config, odds))
+}
+
+func init() {
+	rootCmd.AddCommand(oddsCmd)
+	oddsCmd.Flags().String("bet", "", "Bet type for numbers games: straight, box, set or mini (default every bet type)")
+}
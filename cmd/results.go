@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/kawana77b/loto/internal/store"
+	"github.com/spf13/cobra"
+)
+
+// resultsCmd represents the results command
+var resultsCmd = &cobra.Command{
+	Use:   "results",
+	Short: "Manages the local database of past draw results",
+	Long: `Manages the local database of past draw results.
+The results are stored under $XDG_DATA_HOME/loto (~/.local/share/loto by default).`,
+}
+
+// resultsImportCmd represents the results import command
+var resultsImportCmd = &cobra.Command{
+	Use:   "import <file.csv>",
+	Short: "Imports past draw results from a CSV file",
+	Long: `Imports past draw results from a CSV file.
+Each row is "draw number, date, main numbers..., bonus numbers...".
+The digits of numbers games may also be given in a single column (e.g., "033").
+Every row is validated against the game before anything is stored,
+and stored draws with the same draw number are replaced.`,
+	Example: `  loto results import --type loto6 loto6.csv`,
+	Args:    cobra.ExactArgs(1),
+	PreRunE: preRunResultsImport,
+	RunE:    runResultsImport,
+}
+
+// resultsListCmd represents the results list command
+var resultsListCmd = &cobra.Command{
+	Use:               "list [type]",
+	Aliases:           []string{"ls"},
+	Short:             "Displays the stored draw results",
+	Long:              `Displays the stored draw results, the latest draw first.`,
+	Args:              cobra.RangeArgs(0, 1),
+	ValidArgsFunction: completeLotteryTypes,
+	PreRunE:           preRunResultsList,
+	RunE:              runResultsList,
+}
+
+type resultsOptions struct {
+	lotteryType loto.LotteryType
+	length      int
+}
+
+var resultsOpts resultsOptions
+
+func preRunResultsImport(cmd *cobra.Command, args []string) error {
+	// --type
+	lt, _ := cmd.Flags().GetString("type")
+	resultsOpts.lotteryType = loto.LotteryType(lt)
+	if err := resultsOpts.lotteryType.Validate(); err != nil {
+		return err
+	}
+	return rejectJumbo(cmd, resultsOpts.lotteryType)
+}
+
+func runResultsImport(cmd *cobra.Command, args []string) error {
+	results, err := store.DefaultResultStore()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	summary, err := results.Import(resultsOpts.lotteryType, f)
+	if err != nil {
+		return fmt.Errorf("failed to import %s: %w", args[0], err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Imported %s results: %d added, %d updated\n", resultsOpts.lotteryType, summary.Added, summary.Updated)
+	return nil
+}
+
+func preRunResultsList(cmd *cobra.Command, args []string) error {
+	// lottery type
+	lotteryType, err := lotteryTypeFromArgs(args)
+	if err != nil {
+		return err
+	}
+	resultsOpts.lotteryType = lotteryType
+
+	// --length
+	resultsOpts.length, _ = cmd.Flags().GetInt("length")
+	return nil
+}
+
+func runResultsList(cmd *cobra.Command, args []string) error {
+	results, err := store.DefaultResultStore()
+	if err != nil {
+		return err
+	}
+	stored, err := results.Load(resultsOpts.lotteryType)
+	if err != nil {
+		return err
+	}
+
+	// Latest draws first
+	if resultsOpts.length > 0 && len(stored) > resultsOpts.length {
+		stored = stored[len(stored)-resultsOpts.length:]
+	}
+
+	category := loto.GetCategory(resultsOpts.lotteryType)
+	config, _ := loto.Lookup(resultsOpts.lotteryType)
+	hasBonus := config.Bonus > 0
+	data := &loto.Dataset{
+		Columns: []loto.Column{
+			{Key: "draw", Title: "Draw"},
+			{Key: "date", Title: "Date"},
+			{Key: "numbers", Title: "Numbers"},
+		},
+	}
+	if hasBonus {
+		data.Columns = append(data.Columns, loto.Column{Key: "bonus", Title: "Bonus"})
+	}
+	for i := len(stored) - 1; i >= 0; i-- {
+		result := stored[i]
+		row := []any{
+			result.Number,
+			result.Date.String(),
+			loto.NewNumbers(category, result.Numbers),
+		}
+		if hasBonus {
+			row = append(row, loto.NewNumbers(category, result.Bonus))
+		}
+		data.Append(row...)
+	}
+	return render(cmd, data)
+}
+
+func init() {
+	rootCmd.AddCommand(resultsCmd)
+	resultsCmd.AddCommand(resultsImportCmd)
+	resultsCmd.AddCommand(resultsListCmd)
+
+	resultsImportCmd.Flags().StringP("type", "t", "", "Lottery type of the results")
+	resultsImportCmd.MarkFlagRequired("type")
+	resultsListCmd.Flags().IntP("length", "n", 10, "Number of latest draws to display (0 for all)")
+}
@@ -3,13 +3,15 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kawana77b/loto/internal/loto"
 	"github.com/kawana77b/loto/internal/prompt"
+	"github.com/kawana77b/loto/internal/store"
 	"github.com/kawana77b/loto/internal/util"
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
@@ -20,89 +22,516 @@ var rootCmd = &cobra.Command{
 	Use:   "loto",
 	Short: "Proposing lottery ticket candidates for Japan (Takarakuji)",
 	Long: `Proposing lottery ticket candidates for Japan (Takarakuji).
-Applicable to "Loto" or "Numbers".
+Applicable to "Loto", "Numbers", "Bingo5" or "Jumbo".
 
 This tool is purely a complete random pick;
 it does not analyze or suggest candidates, nor does it guarantee winning.`,
-	Args:    cobra.MatchAll(cobra.RangeArgs(0, 1)),
-	PreRunE: preRunRoot,
-	RunE:    runRoot,
+	Args:              cobra.MatchAll(cobra.RangeArgs(0, 1)),
+	ValidArgsFunction: completeLotteryTypes,
+	PersistentPreRunE: persistentPreRunRoot,
+	PreRunE:           preRunRoot,
+	RunE:              runRoot,
 }
 
 type rootOptions struct {
 	lotteryType loto.LotteryType
 	length      int
+	budget      int
+	pack        loto.PackType
+	picking     pickingOptions
 }
 
 var rootOpts rootOptions
 
-func preRunRoot(cmd *cobra.Command, args []string) error {
-	// lottery type
+// pickingOptions holds the options of the picking flags shared by the commands that pick tickets.
+type pickingOptions struct {
+	bet     loto.BetType
+	include []int
+	exclude []int
+	filters []loto.Filter
+	weights loto.Weights
+}
+
+// gameOptions returns the lottery options derived from the persistent flags.
+func gameOptions(cmd *cobra.Command) []loto.Option {
+	var opts []loto.Option
+
+	// --seed
+	if cmd.Flags().Changed("seed") {
+		seed, _ := cmd.Flags().GetUint64("seed")
+		opts = append(opts, loto.WithSeed(seed))
+	}
+
+	// --secure
+	if secure, _ := cmd.Flags().GetBool("secure"); secure {
+		opts = append(opts, loto.WithSecure())
+	}
+	return opts
+}
+
+// render writes the dataset to the output of the command in the format given by --output.
+func render(cmd *cobra.Command, data *loto.Dataset) error {
+	output, _ := cmd.Flags().GetString("output")
+	return loto.Render(cmd.OutOrStdout(), loto.Format(output), data)
+}
+
+// lotteryTypeFromArgs returns the lottery type given as the first argument.
+// If no argument is given, the user is prompted to select one.
+func lotteryTypeFromArgs(args []string) (loto.LotteryType, error) {
+	var lotteryType loto.LotteryType
 	if len(args) == 0 {
 		lt, _ := prompt.PromptLotteryType()
-		rootOpts.lotteryType = loto.LotteryType(lt)
+		lotteryType = loto.LotteryType(lt)
 	} else {
-		rootOpts.lotteryType = loto.LotteryType(args[0])
+		lotteryType = loto.LotteryType(args[0])
+	}
+	if err := lotteryType.Validate(); err != nil {
+		return "", err
+	}
+	return lotteryType, nil
+}
+
+// rejectJumbo returns an error if the lottery type is a jumbo game, whose tickets the command does not support.
+func rejectJumbo(cmd *cobra.Command, lotteryType loto.LotteryType) error {
+	if loto.GetCategory(lotteryType) == loto.JUMBO {
+		return fmt.Errorf("%s is not available for jumbo games", cmd.CommandPath())
+	}
+	return nil
+}
+
+// persistentPreRunRoot loads the user-defined games and validates the persistent flags shared by every command.
+func persistentPreRunRoot(cmd *cobra.Command, args []string) error {
+	// --games
+	if err := loadGames(cmd); err != nil {
+		return err
 	}
 
+	// --output
+	output, _ := cmd.Flags().GetString("output")
+	return loto.Format(output).Validate()
+}
+
+// loadGames registers the user-defined games of the file given by --games,
+// or of the first games file found in the config directory.
+func loadGames(cmd *cobra.Command) error {
+	if path, _ := cmd.Flags().GetString("games"); path != "" {
+		return loto.LoadGames(path)
+	}
+
+	dir, err := store.ConfigDir()
+	if err != nil {
+		return nil
+	}
+	for _, name := range loto.GamesFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return loto.LoadGames(path)
+		}
+	}
+	return nil
+}
+
+// completeLotteryTypes completes the lottery type argument, including user-defined games.
+func completeLotteryTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	_ = loadGames(cmd)
+	return loto.Names(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeStrategies completes the --strategy flag.
+func completeStrategies(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var names []string
+	for _, s := range loto.Strategies() {
+		names = append(names, s.Name())
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func preRunRoot(cmd *cobra.Command, args []string) error {
+	// lottery type
+	lotteryType, err := lotteryTypeFromArgs(args)
+	if err != nil {
+		return err
+	}
+	rootOpts.lotteryType = lotteryType
+
 	// --length
 	length, _ := cmd.Flags().GetInt("length")
 	rootOpts.length = util.Abs(length)
 
-	// validatation
-	if err := (rootOpts.lotteryType).Validate(); err != nil {
+	// picking flags
+	if rootOpts.picking, err = parsePickingFlags(cmd, rootOpts.lotteryType); err != nil {
 		return err
 	}
+
+	// --pack
+	pack, _ := cmd.Flags().GetString("pack")
+	rootOpts.pack = loto.PackType(pack)
+	config, _ := loto.Lookup(rootOpts.lotteryType)
+	if rootOpts.pack != "" {
+		if err := rootOpts.pack.Validate(config); err != nil {
+			return err
+		}
+	}
+
+	// --budget
+	rootOpts.budget = 0
+	if cmd.Flags().Changed("budget") {
+		rootOpts.budget, _ = cmd.Flags().GetInt("budget")
+		if rootOpts.length, err = config.BudgetLines(rootOpts.picking.bet, rootOpts.budget); err != nil {
+			return fmt.Errorf("--budget: %w", err)
+		}
+		if rootOpts.pack != "" {
+			// Packs are bought whole
+			if rootOpts.length /= loto.PackSize; rootOpts.length == 0 {
+				return fmt.Errorf("--budget: budget %s cannot buy a pack of %s", loto.Yen(rootOpts.budget), loto.Yen(config.Price*loto.PackSize))
+			}
+		}
+	}
+
+	// validatation
 	if rootOpts.length <= 0 {
 		os.Exit(1)
 	}
 	return nil
 }
 
+// parsePickingFlags parses the picking flags for the lottery type.
+func parsePickingFlags(cmd *cobra.Command, lotteryType loto.LotteryType) (pickingOptions, error) {
+	var opts pickingOptions
+	var err error
+
+	// --bet
+	bet, _ := cmd.Flags().GetString("bet")
+	opts.bet = loto.BetType(bet)
+
+	// --include
+	include, _ := cmd.Flags().GetString("include")
+	if opts.include, err = util.ParseInts(include); err != nil {
+		return opts, err
+	}
+
+	// --exclude
+	exclude, _ := cmd.Flags().GetString("exclude")
+	if opts.exclude, err = util.ParseInts(exclude); err != nil {
+		return opts, err
+	}
+
+	// --sum, --odd, --high, --max-consecutive, --max-decade, --max-last-digit
+	if opts.filters, err = filtersFromFlags(cmd); err != nil {
+		return opts, err
+	}
+
+	// --weights, --bias
+	if opts.weights, err = weightsFromFlags(cmd, lotteryType); err != nil {
+		return opts, err
+	}
+
+	// validatation
+	if opts.bet != "" {
+		config, _ := loto.Lookup(lotteryType)
+		if err := opts.bet.Validate(config); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// lotteryOptions returns the lottery options derived from the persistent flags and the picking flags.
+func (p pickingOptions) lotteryOptions(cmd *cobra.Command) ([]loto.Option, error) {
+	opts := gameOptions(cmd)
+	if p.bet != "" {
+		opts = append(opts, loto.WithBet(p.bet))
+	}
+	if len(p.include) > 0 {
+		opts = append(opts, loto.WithInclude(p.include...))
+	}
+	if len(p.exclude) > 0 {
+		opts = append(opts, loto.WithExclude(p.exclude...))
+	}
+	if len(p.filters) > 0 {
+		opts = append(opts, loto.WithFilters(p.filters...))
+	}
+	if cmd.Flags().Changed("max-attempts") {
+		attempts, _ := cmd.Flags().GetInt("max-attempts")
+		opts = append(opts, loto.WithMaxAttempts(attempts))
+	}
+	if name, _ := cmd.Flags().GetString("strategy"); name != "" {
+		strategy, ok := loto.LookupStrategy(name)
+		if !ok {
+			return nil, fmt.Errorf("invalid strategy: %s. See loto strategies", name)
+		}
+		opts = append(opts, loto.WithStrategy(strategy))
+	}
+	if spread, _ := cmd.Flags().GetBool("spread"); spread {
+		opts = append(opts, loto.WithSpread())
+	}
+	if cmd.Flags().Changed("max-overlap") {
+		overlap, _ := cmd.Flags().GetInt("max-overlap")
+		opts = append(opts, loto.WithMaxOverlap(overlap))
+	}
+	if len(p.weights) > 0 {
+		opts = append(opts, loto.WithWeights(p.weights))
+	}
+	return opts, nil
+}
+
+// filtersFromFlags returns the filters given by the filter flags.
+func filtersFromFlags(cmd *cobra.Command) ([]loto.Filter, error) {
+	var filters []loto.Filter
+	rangeFilters := []struct {
+		flag   string
+		filter func(loto.Range) loto.Filter
+	}{
+		{flag: "sum", filter: loto.SumFilter},
+		{flag: "odd", filter: loto.OddFilter},
+		{flag: "high", filter: loto.HighFilter},
+	}
+	for _, f := range rangeFilters {
+		if !cmd.Flags().Changed(f.flag) {
+			continue
+		}
+		value, _ := cmd.Flags().GetString(f.flag)
+		r, err := loto.ParseRange(value)
+		if err != nil {
+			return nil, fmt.Errorf("--%s: %w", f.flag, err)
+		}
+		filters = append(filters, f.filter(r))
+	}
+
+	maxFilters := []struct {
+		flag   string
+		filter func(int) loto.Filter
+	}{
+		{flag: "max-consecutive", filter: loto.MaxConsecutiveFilter},
+		{flag: "max-decade", filter: loto.MaxSameDecadeFilter},
+		{flag: "max-last-digit", filter: loto.MaxSameLastDigitFilter},
+	}
+	for _, f := range maxFilters {
+		if !cmd.Flags().Changed(f.flag) {
+			continue
+		}
+		value, _ := cmd.Flags().GetInt(f.flag)
+		filters = append(filters, f.filter(value))
+	}
+
+	// Numbers filters
+	if noRepdigit, _ := cmd.Flags().GetBool("no-repdigit"); noRepdigit {
+		filters = append(filters, loto.NoRepdigitFilter())
+	}
+	if double, _ := cmd.Flags().GetBool("double"); double {
+		filters = append(filters, loto.DoubleFilter(true))
+	}
+	if noDouble, _ := cmd.Flags().GetBool("no-double"); noDouble {
+		filters = append(filters, loto.DoubleFilter(false))
+	}
+	if boxType, _ := cmd.Flags().GetString("box-type"); boxType != "" {
+		filters = append(filters, loto.BoxTypeFilter(strings.Split(boxType, ",")...))
+	}
+	digits, _ := cmd.Flags().GetStringArray("digit")
+	for _, digit := range digits {
+		pos, value, ok := strings.Cut(digit, "=")
+		p, errP := strconv.Atoi(pos)
+		d, errD := strconv.Atoi(value)
+		if !ok || errP != nil || errD != nil {
+			return nil, fmt.Errorf("--digit: invalid position and digit: %q. It must be like 1=7", digit)
+		}
+		filters = append(filters, loto.DigitAtFilter(p, d))
+	}
+	return filters, nil
+}
+
+// weightsFromFlags returns the weights of the weights file given by --weights,
+// multiplied by the weights derived from the stored results with --bias.
+func weightsFromFlags(cmd *cobra.Command, lotteryType loto.LotteryType) (loto.Weights, error) {
+	weights := loto.Weights{}
+	if path, _ := cmd.Flags().GetString("weights"); path != "" {
+		fileWeights, err := loto.LoadWeights(path)
+		if err != nil {
+			return nil, err
+		}
+		weights = weights.Multiply(fileWeights)
+	}
+
+	if bias, _ := cmd.Flags().GetString("bias"); bias != "" {
+		if err := loto.Bias(bias).Validate(); err != nil {
+			return nil, err
+		}
+		results, err := store.DefaultResultStore()
+		if err != nil {
+			return nil, err
+		}
+		stored, err := results.Load(lotteryType)
+		if err != nil {
+			return nil, err
+		}
+		if len(stored) == 0 {
+			return nil, fmt.Errorf("no results of %s are stored. Import them with loto results import", lotteryType)
+		}
+		draws := make([][]int, len(stored))
+		for i, result := range stored {
+			draws[i] = result.Numbers
+		}
+		config, _ := loto.Lookup(lotteryType)
+		biasWeights, err := loto.FrequencyWeights(config, draws, loto.Bias(bias))
+		if err != nil {
+			return nil, err
+		}
+		weights = weights.Multiply(biasWeights)
+	}
+	return weights, nil
+}
+
 func runRoot(cmd *cobra.Command, args []string) error {
+	if loto.GetCategory(rootOpts.lotteryType) == loto.JUMBO {
+		return runJumbo(cmd)
+	}
+
 	// Create lottery game
-	lottery := loto.NewLottery(rootOpts.lotteryType)
-	if lottery == nil {
-		return fmt.Errorf("failed to create lottery for type: %s", rootOpts.lotteryType)
+	opts, err := rootOpts.picking.lotteryOptions(cmd)
+	if err != nil {
+		return err
+	}
+	if capped, _ := cmd.Flags().GetBool("cap"); capped {
+		opts = append(opts, loto.WithCap())
+	}
+	lottery, err := loto.NewLottery(rootOpts.lotteryType, opts...)
+	if err != nil {
+		return err
+	}
+
+	// Show the probabilities of the numbers instead
+	if show, _ := cmd.Flags().GetBool("show-weights"); show {
+		probabilities, err := lottery.Probabilities(loto.ProbabilitySamples)
+		if err != nil {
+			return err
+		}
+		return render(cmd, loto.WeightsDataset(lottery.Config(), rootOpts.picking.weights, probabilities, loto.ProbabilitySamples))
 	}
 
 	// Pick lottery numbers
-	results := lottery.PickN(rootOpts.length)
-
-	// Display results in table format
-	table := tablewriter.NewWriter(os.Stdout)
-	table.Header([]string{"No", "Result"})
-
-	// Determine if we need zero-padding based on lottery category
-	isLoto := loto.GetCategory(rootOpts.lotteryType) == loto.LOTO
-
-	for i, result := range results {
-		numbers := make([]string, len(result))
-		for j, num := range result {
-			if isLoto {
-				// Loto: 2-digit zero-padded format (e.g., 01, 07, 38)
-				numbers[j] = fmt.Sprintf("%02d", num)
-			} else {
-				// Numbers: no padding (e.g., 8, 3, 3)
-				numbers[j] = strconv.Itoa(num)
-			}
+	results, err := lottery.PickN(rootOpts.length)
+	if err != nil {
+		return err
+	}
+
+	// Record the results in the history
+	next := nextDraw(cmd)
+	if save, _ := cmd.Flags().GetBool("save"); save {
+		entry := store.HistoryEntry{Bet: lottery.Bet(), Tickets: results}
+		if err := saveHistory(cmd, entry, next); err != nil {
+			return err
 		}
+	}
 
-		// Join numbers differently based on type
-		var numbersStr string
-		if isLoto {
-			numbersStr = strings.Join(numbers, ", ")
-		} else {
-			numbersStr = strings.Join(numbers, "")
+	// Display results
+	category := loto.GetCategory(rootOpts.lotteryType)
+	data := loto.PicksDataset(category, lottery.Bet(), results)
+	if showDraw, _ := cmd.Flags().GetBool("show-draw"); showDraw && next != nil {
+		data.Title = fmt.Sprintf("%s %s", rootOpts.lotteryType, next)
+	}
+	data.Footer = loto.CostSummary(len(results), len(results)*lottery.Config().LinePrice(lottery.Bet()))
+	if rootOpts.budget > 0 {
+		data.Footer += fmt.Sprintf(" of the %s budget", loto.Yen(rootOpts.budget))
+	}
+	if lottery.Strategy() == loto.CoverageStrategy {
+		data.Footer += ". Coverage: " + lottery.Coverage(results).String()
+	}
+	return render(cmd, data)
+}
+
+// runJumbo picks jumbo tickets, or packs of them with --pack.
+// Only the groups of the tickets can be chosen, with --include and --exclude.
+func runJumbo(cmd *cobra.Command) error {
+	// Create jumbo game
+	opts, err := rootOpts.picking.lotteryOptions(cmd)
+	if err != nil {
+		return err
+	}
+	if capped, _ := cmd.Flags().GetBool("cap"); capped {
+		opts = append(opts, loto.WithCap())
+	}
+	jumbo, err := loto.NewJumbo(rootOpts.lotteryType, opts...)
+	if err != nil {
+		return err
+	}
+	if show, _ := cmd.Flags().GetBool("show-weights"); show {
+		return fmt.Errorf("--show-weights is not available for jumbo games")
+	}
+
+	// Pick jumbo tickets, or packs of them
+	var tickets []loto.JumboTicket
+	var packs []loto.JumboPack
+	if rootOpts.pack != "" {
+		if packs, err = jumbo.PickPacks(rootOpts.pack, rootOpts.length); err != nil {
+			return err
+		}
+		for _, pack := range packs {
+			tickets = append(tickets, pack.Tickets...)
 		}
+	} else if tickets, err = jumbo.PickN(rootOpts.length); err != nil {
+		return err
+	}
 
-		table.Append([]string{
-			strconv.Itoa(i + 1),
-			numbersStr,
-		})
+	// Record the tickets in the history
+	next := nextDraw(cmd)
+	if save, _ := cmd.Flags().GetBool("save"); save {
+		if err := saveHistory(cmd, store.HistoryEntry{Jumbo: tickets}, next); err != nil {
+			return err
+		}
 	}
 
-	return table.Render()
+	// Display tickets
+	data := loto.JumboDataset(tickets)
+	if packs != nil {
+		data = loto.PacksDataset(packs)
+	}
+	if showDraw, _ := cmd.Flags().GetBool("show-draw"); showDraw && next != nil {
+		data.Title = fmt.Sprintf("%s %s", rootOpts.lotteryType, next)
+	}
+	data.Footer = loto.CostSummary(len(tickets), len(tickets)*jumbo.Config().Price)
+	if packs != nil {
+		data.Footer = fmt.Sprintf("%s, %s", loto.Plural(len(packs), rootOpts.pack.String()+" pack"), data.Footer)
+	}
+	if rootOpts.budget > 0 {
+		data.Footer += fmt.Sprintf(" of the %s budget", loto.Yen(rootOpts.budget))
+	}
+	return render(cmd, data)
+}
+
+// nextDraw returns the next draw of the lottery type, which the picked tickets are for,
+// if they are saved or it is shown (nil otherwise or if the schedule is unknown).
+func nextDraw(cmd *cobra.Command) *loto.ScheduledDraw {
+	save, _ := cmd.Flags().GetBool("save")
+	showDraw, _ := cmd.Flags().GetBool("show-draw")
+	if schedule := scheduleOf(rootOpts.lotteryType); schedule != nil && (save || showDraw) {
+		return &schedule.Next(time.Now(), 1)[0]
+	}
+	return nil
+}
+
+// saveHistory records the picked tickets of the entry for the draw (nil if unknown) in the history store.
+func saveHistory(cmd *cobra.Command, entry store.HistoryEntry, draw *loto.ScheduledDraw) error {
+	history, err := store.DefaultHistoryStore()
+	if err != nil {
+		return err
+	}
+
+	entry.Type = rootOpts.lotteryType
+	entry.CreatedAt = time.Now()
+	if draw != nil {
+		entry.Draw = draw.Number
+	}
+	if cmd.Flags().Changed("seed") {
+		seed, _ := cmd.Flags().GetUint64("seed")
+		entry.Seed = &seed
+	}
+	if _, err := history.Add(entry); err != nil {
+		return fmt.Errorf("failed to save history: %w", err)
+	}
+	return nil
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -118,5 +547,45 @@ func Execute() {
 const quickPickDefaultCount int = 5
 
 func init() {
+	rootCmd.PersistentFlags().Uint64("seed", 0, "Seed the random source so that the same seed always gives the same results")
+	rootCmd.PersistentFlags().Bool("secure", false, "Draw from a cryptographically secure random source")
+	rootCmd.MarkFlagsMutuallyExclusive("seed", "secure")
+	rootCmd.PersistentFlags().String("games", "", "Games file with user-defined games (default ~/.config/loto/games.yaml)")
+	rootCmd.PersistentFlags().StringP("output", "o", string(loto.TABLE), "Output format: table, json, jsonl, csv, tsv, yaml, markdown or plain")
 	rootCmd.Flags().IntP("length", "n", quickPickDefaultCount, "Specify the number of lottery results to pick")
+	rootCmd.Flags().Int("budget", 0, "Pick as many results as the budget in yen buys instead of --length (e.g. 3000)")
+	rootCmd.MarkFlagsMutuallyExclusive("length", "budget")
+	rootCmd.Flags().String("pack", "", "Jumbo games: pick packs of 10 tickets, renban (consecutive) or bara (scattered), and count packs with -n")
+	addPickingFlags(rootCmd)
+	rootCmd.Flags().Bool("show-weights", false, "Show the weight and the estimated probability of each number instead of picking")
+	rootCmd.Flags().Bool("cap", false, "Pick every possible result instead of failing when --length exceeds them")
+	rootCmd.Flags().Bool("save", false, "Record the results in the history (see loto history)")
+	rootCmd.Flags().Bool("show-draw", false, "Label the results with the next draw of the game and its draw number (see loto next)")
+}
+
+// addPickingFlags adds the flags that configure how the tickets are picked.
+func addPickingFlags(cmd *cobra.Command) {
+	cmd.Flags().String("include", "", "Comma-separated numbers that every result must contain (e.g. 7,13)")
+	cmd.Flags().String("exclude", "", "Comma-separated numbers that no result may contain (e.g. 4,9)")
+	cmd.Flags().String("sum", "", "Range of the sum of the numbers of every result (e.g. 100-160)")
+	cmd.Flags().String("odd", "", "Number or range of odd numbers in every result (e.g. 3 or 2-4)")
+	cmd.Flags().String("high", "", "Number or range of numbers in the upper half of the range in every result (e.g. 3 or 2-4)")
+	cmd.Flags().Int("max-consecutive", 0, "Maximum run of consecutive numbers in every result")
+	cmd.Flags().Int("max-decade", 0, "Maximum count of numbers in the same decade (e.g. 10-19) in every result")
+	cmd.Flags().Int("max-last-digit", 0, "Maximum count of numbers sharing the same last digit in every result")
+	cmd.Flags().Bool("no-repdigit", false, "Numbers games: no result is a repdigit (e.g. 777)")
+	cmd.Flags().Bool("double", false, "Numbers games: every result has a digit that appears exactly twice (e.g. 112)")
+	cmd.Flags().Bool("no-double", false, "Numbers games: no result has a digit that appears exactly twice")
+	cmd.MarkFlagsMutuallyExclusive("double", "no-double")
+	cmd.Flags().String("box-type", "", "Numbers games: comma-separated box types of every result (e.g. single for a 6-way box)")
+	cmd.Flags().StringArray("digit", nil, "Numbers games: fix the digit at a position counted from 1, can be repeated (e.g. 1=7)")
+	cmd.Flags().Int("max-attempts", loto.DefaultMaxAttempts, "Number of draws after which picking gives up on the filters")
+	cmd.Flags().String("strategy", "", "Picking strategy, see loto strategies (default depends on the options)")
+	cmd.Flags().Bool("spread", false, "Loto games: spread the numbers across the results so that each number is used about equally (same as --strategy coverage)")
+	cmd.Flags().Int("max-overlap", 0, "Loto games: maximum count of numbers that two results may share")
+	cmd.MarkFlagsMutuallyExclusive("strategy", "spread")
+	cmd.RegisterFlagCompletionFunc("strategy", completeStrategies)
+	cmd.Flags().String("weights", "", "Weights file with the weight of each number (e.g. weights: {7: 3})")
+	cmd.Flags().String("bias", "", "Weight the numbers by their frequency in the stored results: hot or cold")
+	cmd.Flags().String("bet", "", "Bet type for numbers games: straight, box, set or mini (default straight)")
 }
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/spf13/cobra"
+)
+
+// simulateCmd represents the simulate command
+var simulateCmd = &cobra.Command{
+	Use:   "simulate [type]",
+	Short: "Simulates playing the same tickets in many draws",
+	Long: `Simulates playing the same tickets in many draws.
+The tickets are picked with the same flags as loto itself, then played in every simulated draw
+(including the bonus numbers), and the prizes they win are tallied with the standard prizes of each tier.
+Actual prizes are pari-mutuel, so the return is only an estimate.
+
+The draws run in parallel, and the same --seed always gives the same result.`,
+	Example: `  loto simulate loto6 --tickets 10 --draws 1000000
+  loto simulate numbers3 --bet box --draws 104 --seed 1`,
+	Args:              cobra.RangeArgs(0, 1),
+	ValidArgsFunction: completeLotteryTypes,
+	PreRunE:           preRunSimulate,
+	RunE:              runSimulate,
+}
+
+type simulateOptions struct {
+	lotteryType loto.LotteryType
+	tickets     int
+	draws       int
+	picking     pickingOptions
+}
+
+var simulateOpts simulateOptions
+
+func preRunSimulate(cmd *cobra.Command, args []string) error {
+	lotteryType, err := lotteryTypeFromArgs(args)
+	if err != nil {
+		return err
+	}
+	if err := rejectJumbo(cmd, lotteryType); err != nil {
+		return err
+	}
+	simulateOpts.lotteryType = lotteryType
+
+	// --tickets, --draws
+	simulateOpts.tickets, _ = cmd.Flags().GetInt("tickets")
+	if simulateOpts.tickets <= 0 {
+		return fmt.Errorf("--tickets must be positive, got %d", simulateOpts.tickets)
+	}
+	simulateOpts.draws, _ = cmd.Flags().GetInt("draws")
+	if simulateOpts.draws <= 0 {
+		return fmt.Errorf("--draws must be positive, got %d", simulateOpts.draws)
+	}
+
+	// picking flags
+	simulateOpts.picking, err = parsePickingFlags(cmd, lotteryType)
+	return err
+}
+
+func runSimulate(cmd *cobra.Command, args []string) error {
+	opts, err := simulateOpts.picking.lotteryOptions(cmd)
+	if err != nil {
+		return err
+	}
+	lottery, err := loto.NewLottery(simulateOpts.lotteryType, opts...)
+	if err != nil {
+		return err
+	}
+	tickets, err := lottery.PickN(simulateOpts.tickets)
+	if err != nil {
+		return err
+	}
+
+	var simOpts []loto.SimulationOption
+	if cmd.Flags().Changed("workers") {
+		workers, _ := cmd.Flags().GetInt("workers")
+		simOpts = append(simOpts, loto.WithSimulationWorkers(workers))
+	}
+	if stderr := cmd.ErrOrStderr(); isTerminal(stderr) {
+		// Stream the progress on a single line
+		simOpts = append(simOpts, loto.WithSimulationProgress(func(done, total int) {
+			fmt.Fprintf(stderr, "\rSimulating... %d/%d draws", done, total)
+			if done == total {
+				fmt.Fprintln(stderr)
+			}
+		}))
+	}
+	result, err := lottery.Simulate(tickets, simulateOpts.draws, simOpts...)
+	if err != nil {
+		return err
+	}
+	return render(cmd, loto.SimulationDataset(result))
+}
+
+// isTerminal reports whether the writer is a file that is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+	simulateCmd.Flags().Int("tickets", 10, "Number of tickets played in every draw")
+	simulateCmd.Flags().Int("draws", 100000, "Number of simulated draws")
+	simulateCmd.Flags().Int("workers", 0, "Number of goroutines that simulate the draws (default the number of CPUs)")
+	addPickingFlags(simulateCmd)
+}
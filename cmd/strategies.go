@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/spf13/cobra"
+)
+
+// strategiesCmd represents the strategies command
+var strategiesCmd = &cobra.Command{
+	Use:   "strategies",
+	Short: "Displays the available picking strategies",
+	Long: `Displays the available picking strategies.
+Each strategy shows the game categories it supports and the options it takes.`,
+	RunE: runStrategies,
+}
+
+func runStrategies(cmd *cobra.Command, args []string) error {
+	return render(cmd, loto.StrategiesDataset())
+}
+
+func init() {
+	rootCmd.AddCommand(strategiesCmd)
+}
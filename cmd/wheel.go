@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/kawana77b/loto/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// wheelCmd represents the wheel command
+var wheelCmd = &cobra.Command{
+	Use:   "wheel [type]",
+	Short: "Plays every number of a pool in a wheel of lines",
+	Long: `Plays every number of a pool in a wheel of lines.
+Without a guarantee, it is a full wheel: every combination of the pool.
+With --drawn and --match, it is an abbreviated wheel that guarantees that
+if --drawn of the drawn numbers are in the pool, at least one line has --match of them.`,
+	Example: `  loto wheel loto6 --pool 3,7,12,18,22,29,33,41
+  loto wheel loto6 --pool 3,7,12,18,22,29,33,41 --drawn 4 --match 3`,
+	Args:              cobra.RangeArgs(0, 1),
+	ValidArgsFunction: completeLotteryTypes,
+	PreRunE:           preRunWheel,
+	RunE:              runWheel,
+}
+
+type wheelOptions struct {
+	lotteryType loto.LotteryType
+	pool        []int
+	guarantee   *loto.WheelGuarantee
+}
+
+var wheelOpts wheelOptions
+
+func preRunWheel(cmd *cobra.Command, args []string) error {
+	lotteryType, err := lotteryTypeFromArgs(args)
+	if err != nil {
+		return err
+	}
+	wheelOpts.lotteryType = lotteryType
+
+	// --pool
+	pool, _ := cmd.Flags().GetString("pool")
+	if wheelOpts.pool, err = util.ParseInts(pool); err != nil {
+		return err
+	}
+
+	// --drawn, --match
+	if cmd.Flags().Changed("drawn") {
+		drawn, _ := cmd.Flags().GetInt("drawn")
+		match, _ := cmd.Flags().GetInt("match")
+		wheelOpts.guarantee = &loto.WheelGuarantee{Drawn: drawn, Match: match}
+	}
+	return nil
+}
+
+func runWheel(cmd *cobra.Command, args []string) error {
+	wheel, err := loto.NewWheel(wheelOpts.lotteryType, wheelOpts.pool, wheelOpts.guarantee)
+	if err != nil {
+		return err
+	}
+	return render(cmd, loto.WheelDataset(wheel))
+}
+
+func init() {
+	rootCmd.AddCommand(wheelCmd)
+	wheelCmd.Flags().String("pool", "", "Comma-separated numbers of the pool (e.g. 3,7,12,18,22,29,33,41)")
+	wheelCmd.Flags().Int("drawn", 0, "Abbreviated wheel: number of drawn numbers in the pool that the guarantee applies to")
+	wheelCmd.Flags().Int("match", 0, "Abbreviated wheel: number of matches that at least one line is guaranteed to have")
+	wheelCmd.MarkFlagRequired("pool")
+	wheelCmd.MarkFlagsRequiredTogether("drawn", "match")
+}
@@ -0,0 +1,260 @@
+package loto
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// BetType represents how a Numbers ticket is played.
+type BetType string
+
+const (
+	// Bet types of Numbers
+	STRAIGHT = BetType("straight") // All digits must match in order
+	BOX      = BetType("box")      // All digits must match in any order
+	SET      = BetType("set")      // Half straight, half box: wins "set-straight" or "set-box"
+	MINI     = BetType("mini")     // Numbers3 only: the last two digits must match in order
+)
+
+// Prizes of Numbers
+const (
+	STRAIGHT_PRIZE     = "straight"
+	BOX_PRIZE          = "box"
+	SET_STRAIGHT_PRIZE = "set-straight"
+	SET_BOX_PRIZE      = "set-box"
+	MINI_PRIZE         = "mini"
+)
+
+// BetTypes returns all bet types.
+func BetTypes() []BetType {
+	return []BetType{STRAIGHT, BOX, SET, MINI}
+}
+
+// String returns the string representation of the bet type.
+func (b BetType) String() string {
+	return string(b)
+}
+
+// Validate checks if the bet type can be played on the lottery.
+func (b BetType) Validate(config LotteryConfig) error {
+	if !slices.Contains(BetTypes(), b) {
+		return fmt.Errorf("invalid bet type: %s. It must be one of straight, box, set, mini", b)
+	}
+	if config.Category != NUMBERS {
+		return fmt.Errorf("bet types are only available for numbers games")
+	}
+	if b == MINI && config.Count != 3 {
+		return fmt.Errorf("mini bets are only available for numbers3")
+	}
+	return nil
+}
+
+// Prizes returns the prizes that a ticket of the bet type can win, from the highest.
+func (b BetType) Prizes() []string {
+	switch b {
+	case STRAIGHT:
+		return []string{STRAIGHT_PRIZE}
+	case BOX:
+		return []string{BOX_PRIZE}
+	case SET:
+		return []string{SET_STRAIGHT_PRIZE, SET_BOX_PRIZE}
+	case MINI:
+		return []string{MINI_PRIZE}
+	}
+	return nil
+}
+
+// Units returns the number of units a ticket of the bet type costs.
+// A set bet is two units, one played as straight and one as box.
+func (b BetType) Units() int {
+	if b == SET {
+		return 2
+	}
+	return 1
+}
+
+// Digits returns the number of digits on a ticket of the bet type.
+func (b BetType) Digits(config LotteryConfig) int {
+	if b == MINI {
+		return 2
+	}
+	return config.Count
+}
+
+// BoxType classifies a Numbers result by how many distinct orderings its digits have.
+type BoxType struct {
+	Name         string `json:"name"`         // Classification (e.g., "single", "double", "triple")
+	Permutations int    `json:"permutations"` // Number of distinct orderings of the digits
+}
+
+// String returns the classification with its permutation count (e.g., "single (6)").
+func (b BoxType) String() string {
+	return fmt.Sprintf("%s (%d)", b.Name, b.Permutations)
+}
+
+// Boxable reports whether the digits can be played as a box or a set.
+// Repdigits (e.g., 777) have only one ordering and cannot.
+func (b BoxType) Boxable() bool {
+	return b.Permutations > 1
+}
+
+// ClassifyBox returns the box classification of the digits.
+//
+//	Numbers3: single (6), double (3), triple (1)
+//	Numbers4: single (24), double (12), double-double (6), triple (4), quadruple (1)
+func ClassifyBox(digits []int) BoxType {
+	counts := make(map[int]int)
+	for _, d := range digits {
+		counts[d]++
+	}
+
+	// permutations of a multiset: n! / (m1! * m2! * ...)
+	permutations := factorial(len(digits))
+	maxCount, pairs := 0, 0
+	for _, c := range counts {
+		permutations /= factorial(c)
+		maxCount = max(maxCount, c)
+		if c == 2 {
+			pairs++
+		}
+	}
+
+	var name string
+	switch {
+	case maxCount <= 1:
+		name = "single"
+	case maxCount == 2 && pairs == 1:
+		name = "double"
+	case maxCount == 2:
+		name = "double-double"
+	case maxCount == 3:
+		name = "triple"
+	case maxCount == 4:
+		name = "quadruple"
+	default:
+		name = fmt.Sprintf("%d-of-a-kind", maxCount)
+	}
+	return BoxType{Name: name, Permutations: permutations}
+}
+
+// factorial returns n!.
+func factorial(n int) int {
+	result := 1
+	for i := 2; i <= n; i++ {
+		result *= i
+	}
+	return result
+}
+
+// NumbersResult holds the result of checking a Numbers ticket against the winning digits.
+type NumbersResult struct {
+	Bet     BetType // Bet type of the ticket
+	BoxType BoxType // Box classification of the ticket
+	Prize   string  // Prize won (empty if the ticket wins nothing)
+}
+
+// Won reports whether the ticket won a prize.
+func (r NumbersResult) Won() bool {
+	return r.Prize != ""
+}
+
+// CheckNumbers validates the ticket and the winning digits against the given lottery type
+// and returns the prize the ticket wins for the bet type.
+func CheckNumbers(t LotteryType, bet BetType, ticket []int, winning []int) (NumbersResult, error) {
+	config, ok := Lookup(t)
+	if !ok {
+		return NumbersResult{}, fmt.Errorf("invalid lottery type: %s", t)
+	}
+	if err := bet.Validate(config); err != nil {
+		return NumbersResult{}, err
+	}
+	if err := config.ValidateNumbers(winning); err != nil {
+		return NumbersResult{}, fmt.Errorf("invalid winning numbers: %w", err)
+	}
+
+	ticketConfig := config
+	ticketConfig.Count = bet.Digits(config)
+	if err := ticketConfig.ValidateNumbers(ticket); err != nil {
+		return NumbersResult{}, fmt.Errorf("invalid ticket: %w", err)
+	}
+	boxType := ClassifyBox(ticket)
+	if (bet == BOX || bet == SET) && !boxType.Boxable() {
+		return NumbersResult{}, fmt.Errorf("invalid ticket: %s cannot be played as %s", FormatNumbers(NUMBERS, ticket), bet)
+	}
+	return EvaluateNumbers(bet, ticket, winning), nil
+}
+
+// EvaluateNumbers returns the prize the ticket wins against the winning digits for the bet type.
+// The ticket and the winning digits are assumed to be valid for the bet type.
+func EvaluateNumbers(bet BetType, ticket []int, winning []int) NumbersResult {
+	result := NumbersResult{
+		Bet:     bet,
+		BoxType: ClassifyBox(ticket),
+	}
+
+	straight := slices.Equal(ticket, winning)
+	box := sameDigits(ticket, winning)
+
+	switch bet {
+	case STRAIGHT:
+		if straight {
+			result.Prize = STRAIGHT_PRIZE
+		}
+	case BOX:
+		if box {
+			result.Prize = BOX_PRIZE
+		}
+	case SET:
+		if straight {
+			result.Prize = SET_STRAIGHT_PRIZE
+		} else if box {
+			result.Prize = SET_BOX_PRIZE
+		}
+	case MINI:
+		if slices.Equal(ticket, winning[len(winning)-len(ticket):]) {
+			result.Prize = MINI_PRIZE
+		}
+	}
+	return result
+}
+
+// NumbersPayoutRate is the share of the sales of Numbers that is paid out as prizes.
+const NumbersPayoutRate = 0.45
+
+// NumbersPrize returns the standard prize in yen of a winning Numbers ticket (0 if it wins nothing).
+// Numbers prizes are pari-mutuel; the standard prize pays out NumbersPayoutRate of the price
+// times the odds of the bet, so box prizes depend on the box type of the ticket.
+// A set bet is two units, so its prizes add up the prizes of a straight and a box unit.
+//
+//	Numbers3: straight ¥90,000, box ¥15,000 (6-way) or ¥30,000 (3-way), mini ¥9,000
+func NumbersPrize(config LotteryConfig, result NumbersResult) int {
+	size := config.Max - config.Min + 1
+	straight := float64(config.Price) * NumbersPayoutRate * math.Pow(float64(size), float64(config.Count))
+	box := straight / float64(result.BoxType.Permutations)
+
+	var prize float64
+	switch result.Prize {
+	case STRAIGHT_PRIZE:
+		prize = straight
+	case BOX_PRIZE:
+		prize = box
+	case SET_STRAIGHT_PRIZE:
+		prize = straight + box
+	case SET_BOX_PRIZE:
+		prize = box
+	case MINI_PRIZE:
+		prize = float64(config.Price) * NumbersPayoutRate * math.Pow(float64(size), float64(MINI.Digits(config)))
+	}
+	return int(prize)
+}
+
+// sameDigits reports whether a and b contain the same digits in any order.
+func sameDigits(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := slices.Sorted(slices.Values(a))
+	sortedB := slices.Sorted(slices.Values(b))
+	return slices.Equal(sortedA, sortedB)
+}
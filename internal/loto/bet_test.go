@@ -0,0 +1,159 @@
+package loto_test
+
+import (
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestClassifyBox tests the ClassifyBox function
+func TestClassifyBox(t *testing.T) {
+	tests := []struct {
+		digits           []int
+		wantName         string
+		wantPermutations int
+	}{
+		{[]int{1, 2, 3}, "single", 6},
+		{[]int{1, 1, 3}, "double", 3},
+		{[]int{7, 7, 7}, "triple", 1},
+		{[]int{1, 2, 3, 4}, "single", 24},
+		{[]int{1, 1, 3, 4}, "double", 12},
+		{[]int{1, 1, 4, 4}, "double-double", 6},
+		{[]int{1, 1, 1, 4}, "triple", 4},
+		{[]int{0, 0, 0, 0}, "quadruple", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(loto.FormatNumbers(loto.NUMBERS, tt.digits), func(t *testing.T) {
+			got := loto.ClassifyBox(tt.digits)
+			if got.Name != tt.wantName || got.Permutations != tt.wantPermutations {
+				t.Errorf("ClassifyBox() = %v, want %s (%d)", got, tt.wantName, tt.wantPermutations)
+			}
+		})
+	}
+}
+
+// TestCheckNumbers tests the CheckNumbers function for every bet type
+func TestCheckNumbers(t *testing.T) {
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		bet         loto.BetType
+		ticket      []int
+		winning     []int
+		wantPrize   string
+		wantErr     bool
+	}{
+		{"straight hit", loto.NUMBERS_3, loto.STRAIGHT, []int{1, 2, 3}, []int{1, 2, 3}, loto.STRAIGHT_PRIZE, false},
+		{"straight wrong order", loto.NUMBERS_3, loto.STRAIGHT, []int{3, 2, 1}, []int{1, 2, 3}, "", false},
+		{"box any order", loto.NUMBERS_4, loto.BOX, []int{4, 3, 2, 1}, []int{1, 2, 3, 4}, loto.BOX_PRIZE, false},
+		{"box different digits", loto.NUMBERS_4, loto.BOX, []int{4, 3, 2, 2}, []int{1, 2, 3, 4}, "", false},
+		{"set straight", loto.NUMBERS_3, loto.SET, []int{1, 2, 3}, []int{1, 2, 3}, loto.SET_STRAIGHT_PRIZE, false},
+		{"set box", loto.NUMBERS_3, loto.SET, []int{2, 1, 3}, []int{1, 2, 3}, loto.SET_BOX_PRIZE, false},
+		{"mini hit", loto.NUMBERS_3, loto.MINI, []int{2, 3}, []int{1, 2, 3}, loto.MINI_PRIZE, false},
+		{"mini wrong order", loto.NUMBERS_3, loto.MINI, []int{3, 2}, []int{1, 2, 3}, "", false},
+		{"mini on numbers4", loto.NUMBERS_4, loto.MINI, []int{3, 4}, []int{1, 2, 3, 4}, "", true},
+		{"box on repdigit", loto.NUMBERS_3, loto.BOX, []int{7, 7, 7}, []int{7, 7, 7}, "", true},
+		{"bet on loto", loto.LOTO_6, loto.STRAIGHT, []int{1, 2, 3, 4, 5, 6}, []int{1, 2, 3, 4, 5, 6}, "", true},
+		{"invalid bet", loto.NUMBERS_3, loto.BetType("invalid"), []int{1, 2, 3}, []int{1, 2, 3}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := loto.CheckNumbers(tt.lotteryType, tt.bet, tt.ticket, tt.winning)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckNumbers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result.Prize != tt.wantPrize {
+				t.Errorf("CheckNumbers() prize = %q, want %q", result.Prize, tt.wantPrize)
+			}
+		})
+	}
+}
+
+// TestLotteryGame_Bet tests the tickets picked for each bet type
+func TestLotteryGame_Bet(t *testing.T) {
+	t.Run("box tickets are sorted and never repdigits", func(t *testing.T) {
+		lottery, err := loto.NewLottery(loto.NUMBERS_3, loto.WithSeed(1), loto.WithBet(loto.BOX))
+		if err != nil {
+			t.Fatalf("NewLottery() error = %v", err)
+		}
+		results, err := lottery.PickN(100)
+		if err != nil {
+			t.Fatalf("PickN() error = %v", err)
+		}
+		for _, result := range results {
+			if !loto.ClassifyBox(result).Boxable() {
+				t.Errorf("Pick() returned a repdigit for box: %v", result)
+			}
+			for i := 1; i < len(result); i++ {
+				if result[i] < result[i-1] {
+					t.Errorf("Pick() box result not sorted: %v", result)
+				}
+			}
+		}
+	})
+
+	t.Run("mini tickets have two digits", func(t *testing.T) {
+		lottery, err := loto.NewLottery(loto.NUMBERS_3, loto.WithBet(loto.MINI))
+		if err != nil {
+			t.Fatalf("NewLottery() error = %v", err)
+		}
+		if result, err := lottery.Pick(); err != nil || len(result) != 2 {
+			t.Errorf("Pick() = %v, %v, want 2 digits", result, err)
+		}
+		if got := len(lottery.Draw().Numbers); got != 3 {
+			t.Errorf("Draw() length = %v, want 3", got)
+		}
+	})
+
+	t.Run("invalid bet for the game", func(t *testing.T) {
+		if _, err := loto.NewLottery(loto.LOTO_6, loto.WithBet(loto.BOX)); err == nil {
+			t.Error("NewLottery() with box bet on loto6 error = nil, want error")
+		}
+	})
+}
+
+// TestLotteryConfig_BudgetLines tests the price of a line and the number of lines a budget buys
+func TestLotteryConfig_BudgetLines(t *testing.T) {
+	tests := []struct {
+		name      string
+		lottery   loto.LotteryType
+		bet       loto.BetType
+		budget    int
+		wantPrice int
+		wantLines int
+		wantErr   bool
+	}{
+		{"loto6", loto.LOTO_6, "", 3000, 200, 15, false},
+		{"loto7 with change", loto.LOTO_7, "", 1000, 300, 3, false},
+		{"miniloto", loto.LOTO_MINI, "", 200, 200, 1, false},
+		{"numbers3 default bet", loto.NUMBERS_3, "", 1000, 200, 5, false},
+		{"numbers3 box", loto.NUMBERS_3, loto.BOX, 1000, 200, 5, false},
+		{"numbers4 set is two units", loto.NUMBERS_4, loto.SET, 3000, 400, 7, false},
+		{"budget below the price", loto.LOTO_7, "", 299, 300, 0, true},
+		{"set budget below two units", loto.NUMBERS_3, loto.SET, 200, 400, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := loto.Lookup(tt.lottery)
+			if got := config.LinePrice(tt.bet); got != tt.wantPrice {
+				t.Errorf("LinePrice() = %d, want %d", got, tt.wantPrice)
+			}
+			lines, err := config.BudgetLines(tt.bet, tt.budget)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BudgetLines() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if lines != tt.wantLines {
+				t.Errorf("BudgetLines() = %d, want %d", lines, tt.wantLines)
+			}
+		})
+	}
+
+	// Unknown price
+	config := loto.LotteryConfig{Category: loto.LOTO, Count: 5, Min: 1, Max: 30}
+	if _, err := config.BudgetLines("", 1000); err == nil {
+		t.Error("BudgetLines() error = nil, want error for an unknown price")
+	}
+}
@@ -0,0 +1,81 @@
+package loto
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BingoPositions is the number of positions of a bingo ticket, one for each cell of the 3x3 card but the free center.
+const BingoPositions = 8
+
+// bingoCard lays out the positions of a bingo ticket on the 3x3 card, row by row (-1 for the free center).
+var bingoCard = [3][3]int{
+	{0, 1, 2},
+	{3, -1, 4},
+	{5, 6, 7},
+}
+
+// bingoLines are the positions of the 8 lines of the card: the rows, the columns and the diagonals.
+// The free center is always marked, so the lines through it have only two positions.
+var bingoLines = [][]int{
+	{0, 1, 2}, {3, 4}, {5, 6, 7}, // rows
+	{0, 3, 5}, {1, 6}, {2, 4, 7}, // columns
+	{0, 7}, {2, 5}, // diagonals
+}
+
+// countLines returns the number of complete lines of a card whose matching positions are set in the mask.
+func countLines(matched uint) int {
+	lines := 0
+	for _, line := range bingoLines {
+		complete := true
+		for _, pos := range line {
+			if matched&(1<<pos) == 0 {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			lines++
+		}
+	}
+	return lines
+}
+
+// matchPositions returns the mask of the positions where the ticket and the drawn numbers are the same.
+func matchPositions(ticket, numbers []int) uint {
+	var matched uint
+	for i := range min(len(ticket), len(numbers)) {
+		if ticket[i] == numbers[i] {
+			matched |= 1 << i
+		}
+	}
+	return matched
+}
+
+// FormatCard formats the numbers of a bingo ticket as a 3x3 card with a free center, one row per line:
+//
+//	01  06  11
+//	16 FREE 21
+//	26  31  36
+func FormatCard(numbers []int) string {
+	rows := make([]string, len(bingoCard))
+	for i, row := range bingoCard {
+		cells := make([]string, len(row))
+		for j, pos := range row {
+			switch {
+			case pos < 0:
+				cells[j] = "FREE"
+			case pos < len(numbers):
+				cells[j] = fmt.Sprintf("%02d", numbers[pos])
+			default:
+				cells[j] = "--"
+			}
+			if j == 1 && pos >= 0 {
+				// The middle column is as wide as the free center
+				cells[j] = " " + cells[j] + " "
+			}
+		}
+		rows[i] = strings.Join(cells, " ")
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,173 @@
+package loto_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestFormatCard tests that bingo numbers are laid out on a 3x3 card with a free center
+func TestFormatCard(t *testing.T) {
+	want := "01  06  11\n16 FREE 21\n26  31  36"
+	if got := loto.FormatCard([]int{1, 6, 11, 16, 21, 26, 31, 36}); got != want {
+		t.Errorf("FormatCard() = %q, want %q", got, want)
+	}
+	numbers := loto.NewNumbers(loto.BINGO, []int{1, 6, 11, 16, 21, 26, 31, 36})
+	if got := numbers.TableString(); got != want {
+		t.Errorf("Numbers.TableString() = %q, want %q", got, want)
+	}
+	if got := numbers.String(); got != "01, 06, 11, 16, 21, 26, 31, 36" {
+		t.Errorf("Numbers.String() = %q, want a single line", got)
+	}
+
+	// Only tables show the card, other text formats keep one line per ticket
+	data := loto.PicksDataset(loto.BINGO, "", [][]int{{1, 6, 11, 16, 21, 26, 31, 36}, {2, 7, 12, 17, 22, 27, 32, 37}})
+	for format, want := range map[loto.Format]string{
+		loto.PLAIN: "01, 06, 11, 16, 21, 26, 31, 36\n02, 07, 12, 17, 22, 27, 32, 37\n",
+		loto.TSV:   "No\tResult\n1\t01, 06, 11, 16, 21, 26, 31, 36\n2\t02, 07, 12, 17, 22, 27, 32, 37\n",
+	} {
+		var buf bytes.Buffer
+		if err := loto.Render(&buf, format, data); err != nil || buf.String() != want {
+			t.Errorf("Render(%s) = %q, %v, want %q", format, buf.String(), err, want)
+		}
+	}
+	var buf bytes.Buffer
+	if err := loto.Render(&buf, loto.TABLE, data); err != nil || !strings.Contains(buf.String(), "16 FREE 21") {
+		t.Errorf("Render(table) = %q, %v, want a card", buf.String(), err)
+	}
+	if got := loto.FormatNumbers(loto.BINGO, []int{1, 6, 11, 16, 21, 26, 31, 36}); got != "01, 06, 11, 16, 21, 26, 31, 36" {
+		t.Errorf("FormatNumbers() = %q", got)
+	}
+}
+
+// TestCheck_Bingo tests that bingo tickets are checked by counting the complete lines of the card
+func TestCheck_Bingo(t *testing.T) {
+	draw := loto.Draw{Numbers: []int{1, 6, 11, 16, 21, 26, 31, 36}}
+
+	tests := []struct {
+		name        string
+		ticket      []int
+		wantMatches int
+		wantLines   int
+		wantRank    int // 0 if the ticket wins nothing
+	}{
+		{"every cell", []int{1, 6, 11, 16, 21, 26, 31, 36}, 8, 8, 1},
+		{"all but an edge", []int{1, 7, 11, 16, 21, 26, 31, 36}, 7, 6, 2},
+		{"all but a corner", []int{2, 6, 11, 16, 21, 26, 31, 36}, 7, 5, 3},
+		{"diagonal only", []int{1, 7, 12, 17, 22, 27, 32, 36}, 2, 1, 7},
+		{"middle row and column", []int{2, 6, 12, 16, 21, 27, 31, 37}, 4, 2, 6},
+		{"corners of both diagonals", []int{1, 7, 11, 17, 22, 26, 32, 36}, 4, 2, 6},
+		{"edges without a line", []int{2, 6, 12, 17, 22, 27, 32, 37}, 1, 0, 0},
+		{"nothing", []int{2, 7, 12, 17, 22, 27, 32, 37}, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := loto.Check(loto.BINGO_5, tt.ticket, draw)
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if result.Main != tt.wantMatches || result.Lines != tt.wantLines {
+				t.Errorf("Check() = %d matches and %d lines, want %d and %d", result.Main, result.Lines, tt.wantMatches, tt.wantLines)
+			}
+			rank := 0
+			if result.Won() {
+				rank = result.Tier.Rank
+			}
+			if rank != tt.wantRank {
+				t.Errorf("Check() rank = %d, want %d", rank, tt.wantRank)
+			}
+		})
+	}
+
+	// Every number must be in the column of its position
+	if _, err := loto.Check(loto.BINGO_5, []int{6, 1, 11, 16, 21, 26, 31, 36}, draw); err == nil {
+		t.Error("Check() with numbers out of their columns error = nil, want error")
+	}
+}
+
+// TestLotteryGame_Bingo tests that bingo tickets and draws take one number from each column
+func TestLotteryGame_Bingo(t *testing.T) {
+	config, _ := loto.Lookup(loto.BINGO_5)
+	lottery, err := loto.NewLottery(loto.BINGO_5, loto.WithSeed(1), loto.WithInclude(7), loto.WithExclude(1, 2, 3, 4))
+	if err != nil {
+		t.Fatalf("NewLottery() error = %v", err)
+	}
+	if got := lottery.ResultSpace().Int64(); got != 5*5*5*5*5*5 {
+		t.Errorf("ResultSpace() = %d, want %d", got, 5*5*5*5*5*5)
+	}
+
+	results, err := lottery.PickN(20)
+	if err != nil {
+		t.Fatalf("PickN() error = %v", err)
+	}
+	for _, result := range results {
+		if err := config.ValidateNumbers(result); err != nil {
+			t.Errorf("PickN() result %v is invalid: %v", result, err)
+		}
+		if result[0] != 5 || result[1] != 7 {
+			t.Errorf("PickN() result %v, want 5 and 7 in the first two columns", result)
+		}
+	}
+	if draw := lottery.Draw(); config.ValidateDraw(draw) != nil {
+		t.Errorf("Draw() = %v is invalid", draw)
+	}
+
+	invalid := map[string][]loto.Option{
+		"two numbers included in a column":  {loto.WithInclude(6, 7)},
+		"every number of a column excluded": {loto.WithExclude(36, 37, 38, 39, 40)},
+		"coverage strategy":                 {loto.WithStrategy(loto.CoverageStrategy)},
+	}
+	for name, opts := range invalid {
+		if _, err := loto.NewLottery(loto.BINGO_5, opts...); err == nil {
+			t.Errorf("NewLottery() with %s error = nil, want error", name)
+		}
+	}
+}
+
+// TestLoadGames_Positions tests loading a user-defined bingo game with its positions
+func TestLoadGames_Positions(t *testing.T) {
+	content := `
+games:
+  - name: bingo24
+    category: bingo
+    count: 8
+    min: 1
+    max: 24
+    positions:
+      - {min: 1, max: 3}
+      - {min: 4, max: 6}
+      - {min: 7, max: 9}
+      - {min: 10, max: 12}
+      - {min: 13, max: 15}
+      - {min: 16, max: 18}
+      - {min: 19, max: 21}
+      - {min: 22, max: 24}
+    tiers:
+      - {rank: 1, lines: 8}
+      - {rank: 2, lines: 5}
+`
+	path := filepath.Join(t.TempDir(), "games.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loto.LoadGames(path); err != nil {
+		t.Fatalf("LoadGames() error = %v", err)
+	}
+
+	config, _ := loto.Lookup("bingo24")
+	if len(config.Positions) != 8 || config.Positions[7] != (loto.Range{Min: 22, Max: 24}) {
+		t.Fatalf("Lookup() positions = %v", config.Positions)
+	}
+	if got := loto.JackpotOdds(config).RatString(); got != "1/6561" {
+		t.Errorf("JackpotOdds() = %s, want 1/6561", got)
+	}
+	result, err := loto.Check("bingo24", []int{1, 4, 7, 10, 13, 16, 19, 23}, loto.Draw{Numbers: []int{1, 4, 7, 10, 13, 16, 19, 22}})
+	if err != nil || !result.Won() || result.Tier.Rank != 2 || result.Lines != 5 {
+		t.Errorf("Check() = %+v, %v, want 2nd prize with 5 lines", result, err)
+	}
+}
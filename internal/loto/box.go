@@ -1,24 +1,50 @@
 package loto
 
 import (
+	"math/rand/v2"
 	"slices"
 
 	"github.com/kawana77b/loto/internal/util"
 )
 
 type Box struct {
-	items []int
+	items   []int
+	src     rand.Source
+	weights map[int]float64
+}
+
+// BoxOption configures a Box.
+type BoxOption func(*Box)
+
+// WithBoxSource sets the random source used by the box.
+// A nil source means the global random source.
+func WithBoxSource(src rand.Source) BoxOption {
+	return func(b *Box) {
+		b.src = src
+	}
+}
+
+// WithBoxWeights makes the box pick its items with probabilities proportional to their weights.
+// Items without a weight have a weight of 1. Weights must be positive.
+func WithBoxWeights(weights map[int]float64) BoxOption {
+	return func(b *Box) {
+		b.weights = weights
+	}
 }
 
 // NewBox creates a new Box containing integers from min to max (inclusive).
-func NewBox(min, max int) *Box {
+func NewBox(min, max int, opts ...BoxOption) *Box {
 	items := make([]int, 0, max-min+1)
 	for i := min; i <= max; i++ {
 		items = append(items, i)
 	}
-	return &Box{
+	b := &Box{
 		items: items,
 	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
 }
 
 // Length returns the number of items in the box.
@@ -31,6 +57,13 @@ func (b *Box) Append(item ...int) {
 	b.items = append(b.items, item...)
 }
 
+// Remove removes every occurrence of the given items from the box.
+func (b *Box) Remove(item ...int) {
+	b.items = slices.DeleteFunc(b.items, func(i int) bool {
+		return slices.Contains(item, i)
+	})
+}
+
 // Clear removes all items from the box.
 func (b *Box) Clear() {
 	b.items = b.items[:0]
@@ -41,7 +74,9 @@ func (b *Box) Clone() *Box {
 	clonedItems := make([]int, len(b.items))
 	copy(clonedItems, b.items)
 	return &Box{
-		items: clonedItems,
+		items:   clonedItems,
+		src:     b.src,
+		weights: b.weights,
 	}
 }
 
@@ -52,7 +87,7 @@ func (b *Box) Sort() {
 
 // Shuffle randomly shuffles the items in the box.
 func (b *Box) Shuffle() {
-	b.items = util.Shuffle(b.items)
+	b.items = util.Shuffle(b.src, b.items)
 }
 
 // Contains checks if the box contains the specified element.
@@ -61,22 +96,64 @@ func (b *Box) Contains(item int) bool {
 }
 
 // PickN randomly selects and returns n unique items from the box.
+// If n exceeds the number of items, all items are returned in random order.
+// With weights, the items are drawn one by one without replacement,
+// each with a probability proportional to its weight among the items left.
 func (b *Box) PickN(n int) []int {
 	if n <= 0 {
 		return []int{}
 	}
-	shuffled := util.Shuffle(b.items)
-	return shuffled[:n]
+	if b.weights == nil {
+		shuffled := util.Shuffle(b.src, b.items)
+		return shuffled[:min(n, len(shuffled))]
+	}
+
+	items := slices.Clone(b.items)
+	weights := b.itemWeights()
+	result := make([]int, 0, min(n, len(items)))
+	for len(result) < n && len(items) > 0 {
+		i := util.WeightedIndex(b.src, weights)
+		result = append(result, items[i])
+		items = slices.Delete(items, i, i+1)
+		weights = slices.Delete(weights, i, i+1)
+	}
+	return result
 }
 
 // PickDupN randomly selects and returns n items from the box, allowing for duplicates.
+// With weights, each item is drawn with a probability proportional to its weight.
 func (b *Box) PickDupN(n int) []int {
 	if n <= 0 {
 		return []int{}
 	}
 	result := make([]int, n)
+	if b.weights == nil {
+		for i := range result {
+			result[i], _ = util.RandomPick(b.src, b.items)
+		}
+		return result
+	}
+
+	weights := b.itemWeights()
 	for i := range result {
-		result[i], _ = util.RandomPick(b.items)
+		result[i] = b.items[util.WeightedIndex(b.src, weights)]
 	}
 	return result
 }
+
+// Weight returns the weight of the item (1 if the box has no weight for it).
+func (b *Box) Weight(item int) float64 {
+	if w, ok := b.weights[item]; ok {
+		return w
+	}
+	return 1
+}
+
+// itemWeights returns the weights of the items, in the order of the items.
+func (b *Box) itemWeights() []float64 {
+	weights := make([]float64, len(b.items))
+	for i, item := range b.items {
+		weights[i] = b.Weight(item)
+	}
+	return weights
+}
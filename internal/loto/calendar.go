@@ -0,0 +1,129 @@
+package loto
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// calendarProductID is the product identifier (PRODID) of the exported calendars.
+const calendarProductID = "-//kawana77b//loto//EN"
+
+// icsTimeLayout is the layout of a UTC date-time in iCalendar.
+const icsTimeLayout = "20060102T150405Z"
+
+// icsLineLimit is the maximum length of a content line in octets, without the line break.
+const icsLineLimit = 75
+
+// drawDuration is the length of a draw event in a calendar.
+const drawDuration = 15 * time.Minute
+
+// Calendar is an iCalendar (RFC 5545) calendar with an event for every upcoming draw.
+type Calendar struct {
+	Draws    map[LotteryType][]ScheduledDraw // Draws of each lottery type
+	Reminder time.Duration                   // Time before sales close to remind of each draw (0 for no reminder)
+	Created  time.Time                       // Time the calendar is created (DTSTAMP of the events)
+}
+
+// Encode writes the calendar in the iCalendar format.
+// The events are sorted by lottery type and then by time, and each event has a UID made of
+// the lottery type and the draw number, so that importing the calendar again updates the same events.
+func (c Calendar) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	writeLine := func(name, value string) {
+		writeContentLine(bw, name+":"+value)
+	}
+
+	writeLine("BEGIN", "VCALENDAR")
+	writeLine("VERSION", "2.0")
+	writeLine("PRODID", calendarProductID)
+	writeLine("CALSCALE", "GREGORIAN")
+	writeLine("METHOD", "PUBLISH")
+	for _, t := range slices.Sorted(maps.Keys(c.Draws)) {
+		for _, draw := range c.Draws[t] {
+			writeLine("BEGIN", "VEVENT")
+			writeLine("UID", fmt.Sprintf("%s-%d@loto", t, draw.Number))
+			writeLine("DTSTAMP", c.Created.UTC().Format(icsTimeLayout))
+			writeLine("DTSTART", draw.Time.UTC().Format(icsTimeLayout))
+			writeLine("DTEND", draw.Time.Add(drawDuration).UTC().Format(icsTimeLayout))
+			writeLine("SUMMARY", escapeText(fmt.Sprintf("%s draw %d", t, draw.Number)))
+			writeLine("DESCRIPTION", escapeText(fmt.Sprintf("Sales close at %s.", draw.SalesClose.Format("15:04 MST"))))
+			writeLine("TRANSP", "TRANSPARENT")
+			if c.Reminder > 0 {
+				// The trigger is relative to the start of the event
+				writeLine("BEGIN", "VALARM")
+				writeLine("ACTION", "DISPLAY")
+				writeLine("DESCRIPTION", escapeText(fmt.Sprintf("%s sales close at %s", t, draw.SalesClose.Format("15:04 MST"))))
+				writeLine("TRIGGER", icsDuration(-(draw.Time.Sub(draw.SalesClose) + c.Reminder)))
+				writeLine("END", "VALARM")
+			}
+			writeLine("END", "VEVENT")
+		}
+	}
+	writeLine("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// writeContentLine writes a content line ended with CRLF,
+// folded into lines of at most 75 octets without splitting a UTF-8 character.
+func writeContentLine(w *bufio.Writer, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(line[i]) {
+			i--
+		}
+		w.WriteString(line[:i])
+		w.WriteString("\r\n ")
+		line = line[i:]
+		// Continuation lines start with a space
+		limit = icsLineLimit - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+// escapeText escapes a TEXT value of iCalendar.
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// icsDuration formats a duration as an iCalendar DURATION value (e.g., "-PT45M"), to the second.
+func icsDuration(d time.Duration) string {
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	d = d.Round(time.Second)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	d -= hours * time.Hour
+	minutes := d / time.Minute
+	seconds := (d - minutes*time.Minute) / time.Second
+
+	b.WriteByte('P')
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if d == 0 && hours == 0 && days > 0 {
+		return b.String()
+	}
+	b.WriteByte('T')
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if seconds > 0 || (hours == 0 && minutes == 0) {
+		fmt.Fprintf(&b, "%dS", seconds)
+	}
+	return b.String()
+}
@@ -0,0 +1,124 @@
+package loto_test
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestCalendar_Encode tests the events and reminders of an exported calendar
+func TestCalendar_Encode(t *testing.T) {
+	schedule := loto.Schedule{
+		Weekdays:   []string{"fri"},
+		DrawTime:   "18:45",
+		SalesClose: "18:30",
+		Reference:  loto.ScheduleReference{Number: 100, Date: "2021-12-10"},
+	}
+	from := time.Date(2021, 12, 10, 12, 0, 0, 0, loto.JST)
+	created := time.Date(2021, 12, 10, 3, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		calendar loto.Calendar
+		want     []string // Lines that must be in the calendar
+		wantNot  []string // Lines that must not be in the calendar
+		events   int
+	}{
+		{
+			name: "without reminder",
+			calendar: loto.Calendar{
+				Draws:   map[loto.LotteryType][]loto.ScheduledDraw{loto.LOTO_7: schedule.Next(from, 2)},
+				Created: created,
+			},
+			want: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"UID:loto7-100@loto",
+				"UID:loto7-101@loto",
+				"DTSTAMP:20211210T030000Z",
+				"DTSTART:20211210T094500Z",
+				"DTEND:20211210T100000Z",
+				"SUMMARY:loto7 draw 100",
+				"END:VCALENDAR",
+			},
+			wantNot: []string{"BEGIN:VALARM"},
+			events:  2,
+		},
+		{
+			name: "with reminder",
+			calendar: loto.Calendar{
+				Draws:    map[loto.LotteryType][]loto.ScheduledDraw{loto.LOTO_7: schedule.Next(from, 1)},
+				Reminder: 30 * time.Minute,
+				Created:  created,
+			},
+			want:   []string{"BEGIN:VALARM", "TRIGGER:-PT45M", "END:VALARM"},
+			events: 1,
+		},
+		{
+			name: "reminder in days",
+			calendar: loto.Calendar{
+				Draws:    map[loto.LotteryType][]loto.ScheduledDraw{loto.LOTO_7: schedule.Next(from, 1)},
+				Reminder: 24*time.Hour - 15*time.Minute,
+				Created:  created,
+			},
+			want:   []string{"TRIGGER:-P1D"},
+			events: 1,
+		},
+		{
+			name: "escaped and folded summary",
+			calendar: loto.Calendar{
+				Draws: map[loto.LotteryType][]loto.ScheduledDraw{
+					"weekly,lottery;" + loto.LotteryType(strings.Repeat("x", 80)): schedule.Next(from, 1),
+				},
+				Created: created,
+			},
+			want:   []string{`SUMMARY:weekly\,lottery\;` + strings.Repeat("x", 50), " " + strings.Repeat("x", 30) + " draw 100"},
+			events: 1,
+		},
+		{
+			name:     "no draws",
+			calendar: loto.Calendar{Created: created},
+			want:     []string{"BEGIN:VCALENDAR", "END:VCALENDAR"},
+			events:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.calendar.Encode(&buf); err != nil {
+				t.Fatalf("Encode() error = %v", err)
+			}
+			out := buf.String()
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Errorf("Encode() does not end with CRLF")
+			}
+			lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+			for _, line := range lines {
+				if strings.Contains(line, "\n") {
+					t.Errorf("Encode() line %q is not ended with CRLF", line)
+				}
+				if len(line) > 75 {
+					t.Errorf("Encode() line %q is longer than 75 octets", line)
+				}
+			}
+			for _, want := range tt.want {
+				if !slices.Contains(lines, want) {
+					t.Errorf("Encode() has no line %q:\n%s", want, out)
+				}
+			}
+			for _, line := range tt.wantNot {
+				if slices.Contains(lines, line) {
+					t.Errorf("Encode() has line %q", line)
+				}
+			}
+			if got := strings.Count(out, "BEGIN:VEVENT\r\n"); got != tt.events {
+				t.Errorf("Encode() has %d events, want %d", got, tt.events)
+			}
+		})
+	}
+}
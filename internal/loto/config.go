@@ -1,12 +1,209 @@
 package loto
 
+import (
+	"fmt"
+	"slices"
+)
+
 // LotteryConfig holds the configuration for a lottery type.
 type LotteryConfig struct {
-	Category       LotteryCategory // Category of the lottery (LOTO or NUMBERS)
+	Category       LotteryCategory // Category of the lottery (LOTO, NUMBERS, BINGO or JUMBO)
 	Count          int             // Number of numbers/digits to pick
 	Min            int             // Minimum value in range
 	Max            int             // Maximum value in range
+	Positions      []Range         // Range of the number at each position (empty if every number is drawn from Min-Max)
 	AllowDuplicate bool            // Whether duplicates are allowed (true for Numbers, false for Loto)
+	Bonus          int             // Number of bonus numbers drawn after the main numbers (0 if none)
+	Price          int             // Price of a line (a unit for numbers types) in yen (0 if unknown)
+	Tiers          []PrizeTier     // Prize tiers in ascending rank order (empty if not evaluated by matches)
+	Schedule       *Schedule       // Draw schedule (nil if unknown)
+	Groups         Range           // Groups (組) of the tickets (jumbo types only)
+	Serials        Range           // Serial numbers (番号) of the tickets of a group (jumbo types only)
+}
+
+// PrizeTier describes the matches required to win a prize.
+// A ticket wins the tier when exactly Main main numbers and at least Bonus bonus numbers match,
+// or, for bingo types, when exactly Lines lines of its card are complete.
+type PrizeTier struct {
+	Rank  int `json:"rank" yaml:"rank" toml:"rank"`    // Prize rank (1 for the 1st prize)
+	Main  int `json:"main" yaml:"main" toml:"main"`    // Number of main numbers that must match
+	Bonus int `json:"bonus" yaml:"bonus" toml:"bonus"` // Minimum number of bonus numbers that must match
+	Lines int `json:"lines" yaml:"lines" toml:"lines"` // Number of lines that must be complete (bingo types only)
+	Prize int `json:"prize" yaml:"prize" toml:"prize"` // Prize of a line in yen (0 if unknown)
+}
+
+// Validate checks that the configuration describes a playable lottery.
+func (c LotteryConfig) Validate() error {
+	if c.Category != LOTO && c.Category != NUMBERS && c.Category != BINGO && c.Category != JUMBO {
+		return fmt.Errorf("invalid category: %q. It must be loto, numbers, bingo or jumbo", c.Category)
+	}
+	if c.Category == JUMBO {
+		return c.validateJumbo()
+	}
+	if c.Groups != (Range{}) || c.Serials != (Range{}) {
+		return fmt.Errorf("groups and serial numbers are only available for jumbo games")
+	}
+	if c.Count <= 0 {
+		return fmt.Errorf("count must be positive, got %d", c.Count)
+	}
+	if c.Min > c.Max {
+		return fmt.Errorf("min %d is greater than max %d", c.Min, c.Max)
+	}
+	if c.Bonus < 0 {
+		return fmt.Errorf("bonus must not be negative, got %d", c.Bonus)
+	}
+	if c.Price < 0 {
+		return fmt.Errorf("price must not be negative, got %d", c.Price)
+	}
+	if c.AllowDuplicate && c.Bonus > 0 {
+		return fmt.Errorf("bonus numbers require a game without duplicates")
+	}
+	if size := c.Max - c.Min + 1; !c.AllowDuplicate && c.Count+c.Bonus > size {
+		return fmt.Errorf("cannot draw %d numbers and %d bonus numbers without duplicates from %d numbers", c.Count, c.Bonus, size)
+	}
+	if err := c.validatePositions(); err != nil {
+		return err
+	}
+
+	for i, tier := range c.Tiers {
+		if tier.Rank != i+1 {
+			return fmt.Errorf("prize tiers must be ranked 1, 2, 3, ... in order, got rank %d at position %d", tier.Rank, i+1)
+		}
+		if tier.Main < 0 || tier.Main > c.Count {
+			return fmt.Errorf("prize tier %d: main matches must be between 0 and %d, got %d", tier.Rank, c.Count, tier.Main)
+		}
+		if tier.Bonus < 0 || tier.Bonus > c.Bonus {
+			return fmt.Errorf("prize tier %d: bonus matches must be between 0 and %d, got %d", tier.Rank, c.Bonus, tier.Bonus)
+		}
+		if c.Category == BINGO && (tier.Lines < 1 || tier.Lines > len(bingoLines) || tier.Main != 0) {
+			return fmt.Errorf("prize tier %d: bingo tiers are won by lines between 1 and %d, got %d lines and %d matches", tier.Rank, len(bingoLines), tier.Lines, tier.Main)
+		}
+		if c.Category != BINGO && tier.Lines != 0 {
+			return fmt.Errorf("prize tier %d: lines are only available for bingo games", tier.Rank)
+		}
+		if tier.Prize < 0 {
+			return fmt.Errorf("prize tier %d: prize must not be negative, got %d", tier.Rank, tier.Prize)
+		}
+	}
+	if c.Schedule != nil {
+		return c.Schedule.Validate()
+	}
+	return nil
+}
+
+// ValidateNumbers checks that the numbers are a valid result for the lottery:
+// the count, the range of the game or of each position and, for loto types, that no number is repeated.
+func (c LotteryConfig) ValidateNumbers(numbers []int) error {
+	if len(numbers) != c.Count {
+		return fmt.Errorf("expected %d numbers, got %d", c.Count, len(numbers))
+	}
+	for i, r := range c.Positions {
+		if !r.Contains(numbers[i]) {
+			return fmt.Errorf("number %d at position %d is out of range %s", numbers[i], i+1, r)
+		}
+	}
+	return c.validateRange(numbers)
+}
+
+// Positional reports whether each number of a result is drawn from the range of its position.
+func (c LotteryConfig) Positional() bool {
+	return len(c.Positions) > 0
+}
+
+// validatePositions checks that the positions are ascending ranges within the range of the game,
+// one for each number of a result, so that the numbers of a result are in ascending order. Bingo types must have a position for every cell of the card.
+func (c LotteryConfig) validatePositions() error {
+	if c.Category == BINGO && len(c.Positions) != BingoPositions {
+		return fmt.Errorf("bingo games must have %d positions, got %d", BingoPositions, len(c.Positions))
+	}
+	if !c.Positional() {
+		return nil
+	}
+	if c.Category == NUMBERS || c.AllowDuplicate || c.Bonus > 0 {
+		return fmt.Errorf("positions are only available for games without duplicates and bonus numbers")
+	}
+	if len(c.Positions) != c.Count {
+		return fmt.Errorf("expected a position for each of the %d numbers, got %d positions", c.Count, len(c.Positions))
+	}
+	for i, r := range c.Positions {
+		if r.Min > r.Max || r.Min < c.Min || r.Max > c.Max {
+			return fmt.Errorf("position %d: range %s must be within %d-%d", i+1, r, c.Min, c.Max)
+		}
+		if i > 0 && r.Min <= c.Positions[i-1].Max {
+			return fmt.Errorf("position %d: range %s must be above the range %s of position %d", i+1, r, c.Positions[i-1], i)
+		}
+	}
+	return nil
+}
+
+// validateJumbo checks that the configuration describes a jumbo game: positive groups and serial numbers
+// of whole tens, so that packs have every last digit. Jumbo tickets are not picked from numbers,
+// so the fields of the numbers and the prize tiers must not be set.
+func (c LotteryConfig) validateJumbo() error {
+	if c.Count != 0 || c.Min != 0 || c.Max != 0 || c.Positional() || c.AllowDuplicate || c.Bonus != 0 || len(c.Tiers) > 0 {
+		return fmt.Errorf("jumbo games are defined by their groups and serial numbers, so count, min, max, positions, allow_duplicate, bonus and tiers must not be set")
+	}
+	if c.Groups.Min < 1 || c.Groups.Min > c.Groups.Max {
+		return fmt.Errorf("the groups %s must be a range of positive numbers", c.Groups)
+	}
+	if c.Serials.Min < 0 || c.Serials.Min > c.Serials.Max || c.Serials.Min%10 != 0 || c.Serials.Max%10 != 9 {
+		return fmt.Errorf("the serial numbers %s must be whole tens (e.g. 100000-199999)", c.Serials)
+	}
+	if c.Price < 0 {
+		return fmt.Errorf("price must not be negative, got %d", c.Price)
+	}
+	if c.Schedule != nil {
+		return c.Schedule.Validate()
+	}
+	return nil
+}
+
+// ValidateDraw checks that the draw is a valid result for the lottery, including its bonus numbers.
+// Bonus numbers must not repeat any of the main numbers.
+func (c LotteryConfig) ValidateDraw(draw Draw) error {
+	if err := c.ValidateNumbers(draw.Numbers); err != nil {
+		return err
+	}
+	if len(draw.Bonus) != c.Bonus {
+		return fmt.Errorf("expected %d bonus numbers, got %d", c.Bonus, len(draw.Bonus))
+	}
+	return c.validateRange(slices.Concat(draw.Numbers, draw.Bonus))
+}
+
+// LinePrice returns the price in yen of a line played with the bet type (0 if the price is unknown).
+// Numbers lines cost a unit for every unit of the bet type, and the bet type is ignored for loto types.
+func (c LotteryConfig) LinePrice(bet BetType) int {
+	if c.Category != NUMBERS || bet == "" {
+		return c.Price
+	}
+	return c.Price * bet.Units()
+}
+
+// BudgetLines returns the number of lines played with the bet type that the budget in yen can buy.
+func (c LotteryConfig) BudgetLines(bet BetType, budget int) (int, error) {
+	price := c.LinePrice(bet)
+	if price <= 0 {
+		return 0, fmt.Errorf("the price of a line is unknown")
+	}
+	if budget < price {
+		return 0, fmt.Errorf("budget %s cannot buy a line of %s", Yen(budget), Yen(price))
+	}
+	return budget / price, nil
+}
+
+// validateRange checks that every number is within range and, unless duplicates are allowed, unique.
+func (c LotteryConfig) validateRange(numbers []int) error {
+	seen := make(map[int]bool, len(numbers))
+	for _, num := range numbers {
+		if num < c.Min || num > c.Max {
+			return fmt.Errorf("number %d is out of range %d-%d", num, c.Min, c.Max)
+		}
+		if seen[num] && !c.AllowDuplicate {
+			return fmt.Errorf("number %d is duplicated", num)
+		}
+		seen[num] = true
+	}
+	return nil
 }
 
 /**
@@ -14,16 +211,49 @@ type LotteryConfig struct {
  * References:
  *  https://ja.wikipedia.org/wiki/%E3%83%8A%E3%83%B3%E3%83%90%E3%83%BC%E3%82%BA_(%E5%AE%9D%E3%81%8F%E3%81%98)
  *  https://ja.wikipedia.org/wiki/%E3%83%AD%E3%83%886
+ *  https://ja.wikipedia.org/wiki/%E3%83%AD%E3%83%887
+ *  https://ja.wikipedia.org/wiki/%E3%83%9F%E3%83%8B%E3%83%AD%E3%83%88
+ *  https://ja.wikipedia.org/wiki/%E3%83%93%E3%83%B3%E3%82%B45
+ *  https://ja.wikipedia.org/wiki/%E3%82%B8%E3%83%A3%E3%83%B3%E3%83%9C%E5%AE%9D%E3%81%8F%E3%81%98
+ *
+ * Bingo5 draws one number from each of the 8 columns of 5 numbers (1-5, 6-10, ..., 36-40),
+ * which fill the cells of a 3x3 card around a free center. Its prizes are won by complete lines,
+ * and every tier is pari-mutuel, so its standard amounts are only rough averages.
+ *
+ * The seasonal Jumbo lotteries (Year-end, Dream, Summer and Halloween Jumbo) sell pre-numbered tickets,
+ * each identified by a group (組, 01-200) and a 6-digit serial number (100000-199999).
+ * They are sold by the ticket or in packs of 10: 連番 (renban) packs of consecutive serial numbers
+ * and バラ (bara) packs of scattered ones. Their draws are held once a season and their prizes
+ * are won by the group and the digits of the serial number, so they have neither a schedule nor prize tiers.
+ *
+ * Draws are held at 18:45 JST and sales close at 18:30 on the draw day.
+ * The reference draws of Loto7, Mini Loto and Bingo5 are their first draws, the reference draw of Loto6
+ * is its first Monday draw and the reference draw of Numbers is its 6000th draw.
+ * The draw numbers of the other draws are counted from them.
+ *
+ * The prizes of loto and bingo tiers are the standard amounts of each tier.
+ * Actual prizes are pari-mutuel and vary from draw to draw (except the lowest tiers),
+ * and the 1st prize may grow with a carryover.
  */
 
-// LotteryConfigs holds all lottery type configurations.
-var LotteryConfigs = map[LotteryType]LotteryConfig{
+// builtinConfigs holds the configurations of the built-in lottery types.
+var builtinConfigs = map[LotteryType]LotteryConfig{
 	LOTO_6: {
 		Category:       LOTO,
 		Count:          6,
 		Min:            1,
 		Max:            43,
 		AllowDuplicate: false,
+		Bonus:          1,
+		Price:          200,
+		Tiers: []PrizeTier{
+			{Rank: 1, Main: 6, Prize: 200000000},
+			{Rank: 2, Main: 5, Bonus: 1, Prize: 10000000},
+			{Rank: 3, Main: 5, Prize: 300000},
+			{Rank: 4, Main: 4, Prize: 6800},
+			{Rank: 5, Main: 3, Prize: 1000},
+		},
+		Schedule: loto6Schedule,
 	},
 	LOTO_7: {
 		Category:       LOTO,
@@ -31,6 +261,17 @@ var LotteryConfigs = map[LotteryType]LotteryConfig{
 		Min:            1,
 		Max:            37,
 		AllowDuplicate: false,
+		Bonus:          2,
+		Price:          300,
+		Tiers: []PrizeTier{
+			{Rank: 1, Main: 7, Prize: 600000000},
+			{Rank: 2, Main: 6, Bonus: 1, Prize: 7300000},
+			{Rank: 3, Main: 6, Prize: 730000},
+			{Rank: 4, Main: 5, Prize: 9100},
+			{Rank: 5, Main: 4, Prize: 1400},
+			{Rank: 6, Main: 3, Bonus: 1, Prize: 1000},
+		},
+		Schedule: loto7Schedule,
 	},
 	LOTO_MINI: {
 		Category:       LOTO,
@@ -38,6 +279,15 @@ var LotteryConfigs = map[LotteryType]LotteryConfig{
 		Min:            1,
 		Max:            31,
 		AllowDuplicate: false,
+		Bonus:          1,
+		Price:          200,
+		Tiers: []PrizeTier{
+			{Rank: 1, Main: 5, Prize: 10000000},
+			{Rank: 2, Main: 4, Bonus: 1, Prize: 150000},
+			{Rank: 3, Main: 4, Prize: 10000},
+			{Rank: 4, Main: 3, Prize: 1000},
+		},
+		Schedule: minilotoSchedule,
 	},
 	NUMBERS_3: {
 		Category:       NUMBERS,
@@ -45,6 +295,8 @@ var LotteryConfigs = map[LotteryType]LotteryConfig{
 		Min:            0,
 		Max:            9,
 		AllowDuplicate: true,
+		Price:          200,
+		Schedule:       numbersSchedule,
 	},
 	NUMBERS_4: {
 		Category:       NUMBERS,
@@ -52,5 +304,69 @@ var LotteryConfigs = map[LotteryType]LotteryConfig{
 		Min:            0,
 		Max:            9,
 		AllowDuplicate: true,
+		Price:          200,
+		Schedule:       numbersSchedule,
+	},
+	BINGO_5: {
+		Category:       BINGO,
+		Count:          8,
+		Min:            1,
+		Max:            40,
+		AllowDuplicate: false,
+		Price:          200,
+		Positions: []Range{
+			{Min: 1, Max: 5}, {Min: 6, Max: 10}, {Min: 11, Max: 15}, {Min: 16, Max: 20},
+			{Min: 21, Max: 25}, {Min: 26, Max: 30}, {Min: 31, Max: 35}, {Min: 36, Max: 40},
+		},
+		Tiers: []PrizeTier{
+			{Rank: 1, Lines: 8, Prize: 5555500},
+			{Rank: 2, Lines: 6, Prize: 69400},
+			{Rank: 3, Lines: 5, Prize: 12600},
+			{Rank: 4, Lines: 4, Prize: 6000},
+			{Rank: 5, Lines: 3, Prize: 2000},
+			{Rank: 6, Lines: 2, Prize: 1000},
+			{Rank: 7, Lines: 1, Prize: 300},
+		},
+		Schedule: bingo5Schedule,
+	},
+	JUMBO_TAKARAKUJI: {
+		Category: JUMBO,
+		Price:    300,
+		Groups:   Range{Min: 1, Max: 200},
+		Serials:  Range{Min: 100000, Max: 199999},
 	},
 }
+
+// Schedules of the built-in lottery types
+var (
+	loto6Schedule = &Schedule{
+		Weekdays:   []string{"mon", "thu"},
+		DrawTime:   "18:45",
+		SalesClose: "18:30",
+		Reference:  ScheduleReference{Number: 543, Date: "2011-04-04"},
+	}
+	loto7Schedule = &Schedule{
+		Weekdays:   []string{"fri"},
+		DrawTime:   "18:45",
+		SalesClose: "18:30",
+		Reference:  ScheduleReference{Number: 1, Date: "2013-04-05"},
+	}
+	minilotoSchedule = &Schedule{
+		Weekdays:   []string{"tue"},
+		DrawTime:   "18:45",
+		SalesClose: "18:30",
+		Reference:  ScheduleReference{Number: 1, Date: "1999-04-13"},
+	}
+	numbersSchedule = &Schedule{
+		Weekdays:   []string{"mon", "tue", "wed", "thu", "fri"},
+		DrawTime:   "18:45",
+		SalesClose: "18:30",
+		Reference:  ScheduleReference{Number: 6000, Date: "2022-06-20"},
+	}
+	bingo5Schedule = &Schedule{
+		Weekdays:   []string{"wed"},
+		DrawTime:   "18:45",
+		SalesClose: "18:30",
+		Reference:  ScheduleReference{Number: 1, Date: "2017-04-05"},
+	}
+)
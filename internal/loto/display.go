@@ -1,46 +1,465 @@
 package loto
 
 import (
+	"encoding/json"
 	"fmt"
-	"io"
-	"sort"
+	"maps"
+	"math"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/olekukonko/tablewriter"
+	"github.com/kawana77b/loto/internal/util"
+	"gopkg.in/yaml.v3"
 )
 
 // Names returns all available lottery type names sorted alphabetically.
 func Names() []string {
-	names := make([]string, 0, len(LotteryConfigs))
-	for lotteryType := range LotteryConfigs {
+	types := DefaultRegistry.All()
+	names := make([]string, 0, len(types))
+	for _, lotteryType := range types {
 		names = append(names, lotteryType.String())
 	}
-	sort.Strings(names)
 	return names
 }
 
-// Table creates a formatted table of all available lottery types with their configurations.
-func Table(w io.Writer) (*tablewriter.Table, error) {
-	table := tablewriter.NewWriter(w)
-	table.Header([]string{"Name", "Count", "Min", "Max", "Allow Duplicates"})
+// ConfigsDataset creates a dataset of all available lottery types with their configurations.
+// With odds, it also shows the odds of matching every main number of each lottery type.
+func ConfigsDataset(odds bool) (*Dataset, error) {
+	data := &Dataset{
+		Columns: []Column{
+			{Key: "name", Title: "Name"},
+			{Key: "count", Title: "Count"},
+			{Key: "min", Title: "Min"},
+			{Key: "max", Title: "Max"},
+			{Key: "bonus", Title: "Bonus"},
+			{Key: "allow_duplicate", Title: "Allow Duplicates"},
+			{Key: "price", Title: "Price"},
+		},
+	}
+	if odds {
+		data.Columns = append(data.Columns, Column{Key: "jackpot_odds", Title: "Jackpot Odds"})
+	}
 
 	for _, name := range Names() {
-		config, ok := LotteryConfigs[LotteryType(name)]
+		config, ok := Lookup(LotteryType(name))
 		if !ok {
 			return nil, fmt.Errorf("invalid lottery type: %s", name)
 		}
 
-		allowDup := "No"
-		if config.AllowDuplicate {
-			allowDup = "Yes"
+		row := []any{
+			name,
+			config.Count,
+			config.Min,
+			config.Max,
+			config.Bonus,
+			YesNo(config.AllowDuplicate),
+			priceCell(config.Price),
+		}
+		if config.Category == JUMBO {
+			// Jumbo tickets are a group and a serial number, so they have no numbers
+			row = []any{name, nil, nil, nil, nil, nil, priceCell(config.Price)}
 		}
+		if odds {
+			row = append(row, oddsCell(JackpotOdds(config)))
+		}
+		data.Append(row...)
+	}
+	return data, nil
+}
 
-		table.Append([]string{
-			name,
-			fmt.Sprintf("%d", config.Count),
-			fmt.Sprintf("%d", config.Min),
-			fmt.Sprintf("%d", config.Max),
-			allowDup,
-		})
+// StrategiesDataset creates a dataset of all available strategies with the categories and options they support.
+func StrategiesDataset() *Dataset {
+	data := &Dataset{
+		Columns: []Column{
+			{Key: "name", Title: "Name"},
+			{Key: "categories", Title: "Categories"},
+			{Key: "options", Title: "Options"},
+			{Key: "description", Title: "Description"},
+		},
+	}
+
+	for _, s := range Strategies() {
+		var categories []string
+		for _, category := range []LotteryCategory{LOTO, NUMBERS, BINGO} {
+			if s.Supports(category) {
+				categories = append(categories, string(category))
+			}
+		}
+		var options any
+		if len(s.Params()) > 0 {
+			options = strings.Join(s.Params(), ", ")
+		}
+		data.Append(s.Name(), strings.Join(categories, ", "), options, s.Description())
+	}
+	return data
+}
+
+// PicksDataset creates a dataset of picked results.
+// Numbers results also show their bet type and box type, and bingo results are shown as cards.
+func PicksDataset(category LotteryCategory, bet BetType, results [][]int) *Dataset {
+	data := &Dataset{
+		Columns: []Column{
+			{Key: "no", Title: "No", Index: true},
+			{Key: "result", Title: "Result"},
+		},
+	}
+	isNumbers := category == NUMBERS
+	if isNumbers {
+		data.Columns = append(data.Columns,
+			Column{Key: "bet", Title: "Bet"},
+			Column{Key: "box_type", Title: "Box Type"},
+		)
+	}
+
+	for i, result := range results {
+		row := []any{i + 1, NewNumbers(category, result)}
+		if isNumbers {
+			// Numbers: show what kind of ticket is being bought
+			var boxType any
+			if bet != MINI {
+				boxType = ClassifyBox(result)
+			}
+			row = append(row, bet, boxType)
+		}
+		data.Append(row...)
+	}
+	return data
+}
+
+// JumboDataset creates a dataset of picked jumbo tickets.
+func JumboDataset(tickets []JumboTicket) *Dataset {
+	data := &Dataset{
+		Columns: []Column{
+			{Key: "no", Title: "No", Index: true},
+			{Key: "ticket", Title: "Ticket"},
+		},
+	}
+	for i, ticket := range tickets {
+		data.Append(i+1, ticket)
+	}
+	return data
+}
+
+// PacksDataset creates a dataset of the tickets of jumbo packs, numbered across the packs.
+func PacksDataset(packs []JumboPack) *Dataset {
+	data := &Dataset{
+		Columns: []Column{
+			{Key: "no", Title: "No", Index: true},
+			{Key: "pack", Title: "Pack"},
+			{Key: "type", Title: "Type"},
+			{Key: "ticket", Title: "Ticket"},
+		},
+	}
+	for i, pack := range packs {
+		for _, ticket := range pack.Tickets {
+			data.Append(len(data.Rows)+1, i+1, pack.Type, ticket)
+		}
+	}
+	return data
+}
+
+// WheelDataset creates a dataset of the lines of a wheel.
+// The title describes the wheel and the footer shows the line count and the cost.
+func WheelDataset(w *Wheel) *Dataset {
+	data := PicksDataset(LOTO, "", w.Lines)
+	if w.Guarantee == nil {
+		data.Title = fmt.Sprintf("Full wheel of %d numbers", len(w.Pool))
+	} else {
+		data.Title = fmt.Sprintf("Abbreviated wheel of %d numbers: %s", len(w.Pool), w.Guarantee)
+	}
+	data.Footer = CostSummary(len(w.Lines), w.Cost())
+	return data
+}
+
+// WeightsDataset creates a dataset of the weight of each number of the lottery
+// and the probability that a picked ticket contains it, estimated from the given number of samples.
+// The footer says that the probabilities are estimates.
+func WeightsDataset(config LotteryConfig, weights Weights, probabilities map[int]float64, samples int) *Dataset {
+	data := &Dataset{
+		Columns: []Column{
+			{Key: "number", Title: "Number"},
+			{Key: "weight", Title: "Weight"},
+			{Key: "estimated_probability", Title: "Estimated Probability"},
+		},
+		Footer: fmt.Sprintf("Probabilities estimated from %s picked tickets, so they vary slightly with the seed", groupDigits(int64(samples))),
+	}
+	for n := config.Min; n <= config.Max; n++ {
+		data.Append(
+			n,
+			weights.Weight(n),
+			Percent(probabilities[n]),
+		)
+	}
+	return data
+}
+
+// SimulationDataset creates a dataset of the prizes won in a simulation.
+// The footer shows the cost, the winnings and the return.
+func SimulationDataset(result SimulationResult) *Dataset {
+	data := &Dataset{
+		Columns: []Column{
+			{Key: "prize", Title: "Prize"},
+			{Key: "hits", Title: "Hits"},
+			{Key: "odds", Title: "Odds"},
+			{Key: "winnings", Title: "Winnings"},
+		},
+	}
+	for _, prize := range result.Prizes {
+		var odds any
+		if prize.Hits > 0 {
+			odds = Odds(float64(result.Lines()) / float64(prize.Hits))
+		}
+		data.Append(prize.Name, prize.Hits, odds, Yen(prize.Winnings))
+	}
+
+	data.Footer = fmt.Sprintf("%s in %s: cost %s, won %s",
+		Plural(result.Tickets, "ticket"), Plural(result.Draws, "draw"), Yen(result.Cost), Yen(result.Winnings))
+	if result.Cost > 0 {
+		data.Footer += fmt.Sprintf(", return %s", Percent(result.Return()))
+	}
+	return data
+}
+
+// OddsDataset creates a dataset of the odds of every prize of the lottery.
+// Loto types show the matches of each tier, bingo types the lines of each tier,
+// and the footer shows the odds of winning any prize.
+// Numbers types show the bet type and the box type of each prize.
+func OddsDataset(config LotteryConfig, odds []PrizeOdds) *Dataset {
+	data := &Dataset{
+		Columns: []Column{
+			{Key: "prize", Title: "Prize"},
+		},
+	}
+	isNumbers := config.Category == NUMBERS
+	isBingo := config.Category == BINGO
+	switch {
+	case isNumbers:
+		data.Columns = append(data.Columns,
+			Column{Key: "bet", Title: "Bet"},
+			Column{Key: "box_type", Title: "Box Type"},
+		)
+	case isBingo:
+		data.Columns = append(data.Columns, Column{Key: "lines", Title: "Lines"})
+	default:
+		data.Columns = append(data.Columns, Column{Key: "matches", Title: "Matches"})
+	}
+	data.Columns = append(data.Columns,
+		Column{Key: "probability", Title: "Probability"},
+		Column{Key: "odds", Title: "Odds"},
+	)
+
+	for _, o := range odds {
+		row := []any{o.Prize}
+		switch {
+		case isNumbers:
+			var boxType any
+			if o.BoxType != nil {
+				boxType = *o.BoxType
+			}
+			row = append(row, o.Bet, boxType)
+		case isBingo:
+			row = append(row, o.Tier.Lines)
+		default:
+			matches := strconv.Itoa(o.Tier.Main)
+			if o.Tier.Bonus > 0 {
+				matches += fmt.Sprintf(" + %d bonus", o.Tier.Bonus)
+			}
+			row = append(row, matches)
+		}
+		data.Append(append(row, o.Probability, oddsCell(o.Probability))...)
+	}
+
+	if !isNumbers {
+		data.Footer = fmt.Sprintf("Any prize: %v", oddsCell(AnyPrizeOdds(odds)))
+	}
+	return data
+}
+
+// ScheduleDataset creates a dataset of the upcoming draws of each lottery type, by name and then by time.
+func ScheduleDataset(draws map[LotteryType][]ScheduledDraw) *Dataset {
+	data := &Dataset{
+		Columns: []Column{
+			{Key: "name", Title: "Name"},
+			{Key: "draw", Title: "Draw"},
+			{Key: "date", Title: "Date"},
+			{Key: "weekday", Title: "Weekday"},
+			{Key: "draw_time", Title: "Draw Time"},
+			{Key: "sales_close", Title: "Sales Close"},
+		},
+	}
+	for _, name := range slices.Sorted(maps.Keys(draws)) {
+		for _, draw := range draws[name] {
+			data.Append(
+				name,
+				draw.Number,
+				draw.Time.Format(time.DateOnly),
+				draw.Time.Format("Mon"),
+				draw.Time.Format("15:04 MST"),
+				draw.SalesClose.Format("15:04 MST"),
+			)
+		}
+	}
+	return data
+}
+
+// CostSummary describes the number of lines and their total cost in yen (e.g., "5 lines, ¥1,000"),
+// without the cost if the price of a line is unknown (0).
+func CostSummary(lines, cost int) string {
+	summary := Plural(lines, "line")
+	if cost > 0 {
+		summary += fmt.Sprintf(", %s", Yen(cost))
+	}
+	return summary
+}
+
+// Plural describes a count of a noun, in the plural unless the count is 1 (e.g., "1 line", "1,000 lines").
+func Plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%s %ss", groupDigits(int64(count)), noun)
+}
+
+// Numbers is a dataset cell holding the numbers of a result.
+// It is formatted with FormatNumbers in text formats (as a card with FormatCard for bingo types in tables)
+// and encoded as an array in structured formats.
+type Numbers struct {
+	Category LotteryCategory
+	Values   []int
+}
+
+// NewNumbers creates a dataset cell of the numbers.
+func NewNumbers(category LotteryCategory, values []int) Numbers {
+	return Numbers{
+		Category: category,
+		Values:   values,
+	}
+}
+
+// String returns the numbers formatted for display on a single line.
+func (n Numbers) String() string {
+	return FormatNumbers(n.Category, n.Values)
+}
+
+// TableString returns the numbers formatted for a table, where bingo types are shown as a card.
+func (n Numbers) TableString() string {
+	if n.Category == BINGO {
+		return FormatCard(n.Values)
+	}
+	return n.String()
+}
+
+// MarshalJSON encodes the numbers as an array.
+func (n Numbers) MarshalJSON() ([]byte, error) {
+	if n.Values == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(n.Values)
+}
+
+// MarshalYAML encodes the numbers as a flow sequence (e.g., [1, 7, 38]).
+func (n Numbers) MarshalYAML() (any, error) {
+	node := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, value := range n.Values {
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: strconv.Itoa(value)})
+	}
+	return node, nil
+}
+
+// YesNo is a dataset cell holding a boolean that is displayed as "Yes" or "No" in text formats.
+type YesNo bool
+
+// String returns "Yes" or "No".
+func (b YesNo) String() string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+// Yen is a dataset cell holding an amount of money that is displayed with a yen sign in text formats.
+type Yen int64
+
+// String returns the amount with a yen sign and thousands separators (e.g., "¥1,200").
+func (y Yen) String() string {
+	if y < 0 {
+		return "-¥" + groupDigits(-int64(y))
+	}
+	return "¥" + groupDigits(int64(y))
+}
+
+// Odds is a dataset cell holding the average number of lines per win,
+// displayed as "1 in N" in text formats.
+type Odds float64
+
+// String returns the odds rounded to a whole number of lines (e.g., "1 in 6,096,454").
+func (o Odds) String() string {
+	return "1 in " + groupDigits(int64(math.Round(float64(o))))
+}
+
+// groupDigits formats the integer with thousands separators (e.g., "1,200").
+func groupDigits(n int64) string {
+	digits := strconv.FormatInt(util.Abs(n), 10)
+	var b strings.Builder
+	if n < 0 {
+		b.WriteByte('-')
+	}
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Percent is a dataset cell holding a probability that is displayed as a percentage in text formats.
+type Percent float64
+
+// String returns the probability as a percentage (e.g., "13.95%").
+func (p Percent) String() string {
+	return fmt.Sprintf("%.2f%%", float64(p)*100)
+}
+
+// priceCell returns the price as a dataset cell, or nil if the price is unknown.
+func priceCell(price int) any {
+	if price <= 0 {
+		return nil
+	}
+	return Yen(price)
+}
+
+// oddsCell returns the odds of the probability as a dataset cell, or nil if the probability is zero.
+func oddsCell(probability *big.Rat) any {
+	if probability.Sign() == 0 {
+		return nil
+	}
+	odds, _ := new(big.Rat).Inv(probability).Float64()
+	return Odds(odds)
+}
+
+// FormatNumbers formats the numbers of a result for display according to the lottery category.
+// Bingo numbers are formatted like loto numbers, in the order of their positions.
+func FormatNumbers(category LotteryCategory, numbers []int) string {
+	isLoto := category != NUMBERS
+
+	formatted := make([]string, len(numbers))
+	for i, num := range numbers {
+		if isLoto {
+			// Loto: 2-digit zero-padded format (e.g., 01, 07, 38)
+			formatted[i] = fmt.Sprintf("%02d", num)
+		} else {
+			// Numbers: no padding (e.g., 8, 3, 3)
+			formatted[i] = strconv.Itoa(num)
+		}
+	}
+
+	// Join numbers differently based on type
+	if isLoto {
+		return strings.Join(formatted, ", ")
 	}
-	return table, nil
+	return strings.Join(formatted, "")
 }
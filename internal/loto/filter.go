@@ -0,0 +1,271 @@
+package loto
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kawana77b/loto/internal/util"
+)
+
+// Range is an inclusive range of integers.
+type Range struct {
+	Min int `json:"min" yaml:"min" toml:"min"`
+	Max int `json:"max" yaml:"max" toml:"max"`
+}
+
+// ParseRange parses a range such as "100-160", or a single number such as "3".
+func ParseRange(s string) (Range, error) {
+	min, max, err := util.ParseRange(s)
+	if err != nil {
+		return Range{}, err
+	}
+	return Range{Min: min, Max: max}, nil
+}
+
+// Contains reports whether n is within the range.
+func (r Range) Contains(n int) bool {
+	return r.Min <= n && n <= r.Max
+}
+
+// String returns the range as "min-max", or as a single number if min equals max.
+func (r Range) String() string {
+	if r.Min == r.Max {
+		return fmt.Sprint(r.Min)
+	}
+	return fmt.Sprintf("%d-%d", r.Min, r.Max)
+}
+
+// Filter decides whether a picked ticket is accepted.
+// Tickets that do not pass every filter of a game are drawn again.
+type Filter interface {
+	// Accept reports whether the ticket of the lottery passes the filter.
+	Accept(config LotteryConfig, ticket []int) bool
+	// Validate checks that the filter can be applied to the tickets of the lottery and bet type.
+	Validate(config LotteryConfig, bet BetType) error
+	// String describes the filter (e.g., "sum 100-160").
+	String() string
+}
+
+// filter is a Filter implemented by functions.
+type filter struct {
+	desc     string
+	accept   func(config LotteryConfig, ticket []int) bool
+	validate func(config LotteryConfig, bet BetType) error // nil if the filter applies to every lottery
+}
+
+func (f filter) Accept(config LotteryConfig, ticket []int) bool {
+	return f.accept(config, ticket)
+}
+
+func (f filter) Validate(config LotteryConfig, bet BetType) error {
+	if f.validate == nil {
+		return nil
+	}
+	return f.validate(config, bet)
+}
+
+func (f filter) String() string {
+	return f.desc
+}
+
+// SumFilter accepts tickets whose numbers add up to a total within the range.
+func SumFilter(r Range) Filter {
+	return filter{
+		desc: "sum " + r.String(),
+		accept: func(_ LotteryConfig, ticket []int) bool {
+			sum := 0
+			for _, n := range ticket {
+				sum += n
+			}
+			return r.Contains(sum)
+		},
+	}
+}
+
+// OddFilter accepts tickets with a number of odd numbers within the range.
+// The rest of the numbers are even, so it also sets the odd/even ratio.
+func OddFilter(r Range) Filter {
+	return filter{
+		desc: "odd " + r.String(),
+		accept: func(_ LotteryConfig, ticket []int) bool {
+			return r.Contains(countFunc(ticket, func(n int) bool {
+				return n%2 != 0
+			}))
+		},
+	}
+}
+
+// HighFilter accepts tickets with a number of high numbers within the range.
+// High numbers are in the upper half of the range of the lottery (23-43 for Loto6), the rest are low.
+func HighFilter(r Range) Filter {
+	return filter{
+		desc: "high " + r.String(),
+		accept: func(config LotteryConfig, ticket []int) bool {
+			return r.Contains(countFunc(ticket, func(n int) bool {
+				return n*2 > config.Min+config.Max
+			}))
+		},
+	}
+}
+
+// MaxConsecutiveFilter accepts tickets without a run of more than max consecutive numbers (e.g., 11, 12, 13 is a run of 3).
+func MaxConsecutiveFilter(max int) Filter {
+	return filter{
+		desc: fmt.Sprintf("max consecutive %d", max),
+		accept: func(_ LotteryConfig, ticket []int) bool {
+			return longestRun(ticket) <= max
+		},
+	}
+}
+
+// MaxSameDecadeFilter accepts tickets with at most max numbers in the same decade (e.g., 10-19).
+func MaxSameDecadeFilter(max int) Filter {
+	return filter{
+		desc: fmt.Sprintf("max same decade %d", max),
+		accept: func(_ LotteryConfig, ticket []int) bool {
+			return maxGroupSize(ticket, func(n int) int { return n / 10 }) <= max
+		},
+	}
+}
+
+// MaxSameLastDigitFilter accepts tickets with at most max numbers sharing the same last digit (e.g., 3, 13, 23).
+func MaxSameLastDigitFilter(max int) Filter {
+	return filter{
+		desc: fmt.Sprintf("max same last digit %d", max),
+		accept: func(_ LotteryConfig, ticket []int) bool {
+			return maxGroupSize(ticket, func(n int) int { return n % 10 }) <= max
+		},
+	}
+}
+
+// NoRepdigitFilter accepts numbers tickets that are not repdigits (e.g., 777).
+func NoRepdigitFilter() Filter {
+	return filter{
+		desc: "no repdigit",
+		accept: func(_ LotteryConfig, ticket []int) bool {
+			return ClassifyBox(ticket).Boxable()
+		},
+		validate: numbersOnly("repdigit filters"),
+	}
+}
+
+// DoubleFilter accepts numbers tickets with a digit that appears exactly twice (e.g., 112) if want is true,
+// or without one if want is false.
+func DoubleFilter(want bool) Filter {
+	desc := "double"
+	if !want {
+		desc = "no double"
+	}
+	return filter{
+		desc: desc,
+		accept: func(_ LotteryConfig, ticket []int) bool {
+			counts := make(map[int]int, len(ticket))
+			for _, n := range ticket {
+				counts[n]++
+			}
+			hasDouble := false
+			for _, count := range counts {
+				hasDouble = hasDouble || count == 2
+			}
+			return hasDouble == want
+		},
+		validate: numbersOnly("double filters"),
+	}
+}
+
+// BoxTypeFilter accepts numbers tickets of one of the given box classifications (e.g., "single" for a 6-way box).
+func BoxTypeFilter(names ...string) Filter {
+	return filter{
+		desc: "box type " + strings.Join(names, " or "),
+		accept: func(_ LotteryConfig, ticket []int) bool {
+			return slices.Contains(names, ClassifyBox(ticket).Name)
+		},
+		validate: numbersOnly("box type filters"),
+	}
+}
+
+// DigitAtFilter accepts numbers tickets with the digit at the given position, counted from 1 at the left.
+// It cannot be applied to box bets, whose digits have no order.
+func DigitAtFilter(pos, digit int) Filter {
+	return filter{
+		desc: fmt.Sprintf("digit %d at position %d", digit, pos),
+		accept: func(_ LotteryConfig, ticket []int) bool {
+			return pos >= 1 && pos <= len(ticket) && ticket[pos-1] == digit
+		},
+		validate: func(config LotteryConfig, bet BetType) error {
+			if err := numbersOnly("position filters")(config, bet); err != nil {
+				return err
+			}
+			if bet == BOX {
+				return fmt.Errorf("position filters are not available for box bets")
+			}
+			if digits := bet.Digits(config); pos < 1 || pos > digits {
+				return fmt.Errorf("invalid position: %d. It must be between 1 and %d", pos, digits)
+			}
+			if digit < config.Min || digit > config.Max {
+				return fmt.Errorf("invalid digit: %d. It must be between %d and %d", digit, config.Min, config.Max)
+			}
+			return nil
+		},
+	}
+}
+
+// numbersOnly returns a validation that fails unless the lottery is a numbers game.
+func numbersOnly(what string) func(config LotteryConfig, bet BetType) error {
+	return func(config LotteryConfig, _ BetType) error {
+		if config.Category != NUMBERS {
+			return fmt.Errorf("%s are only available for numbers games", what)
+		}
+		return nil
+	}
+}
+
+// describeFilters returns the descriptions of the filters separated by commas.
+func describeFilters(filters []Filter) string {
+	descs := make([]string, len(filters))
+	for i, f := range filters {
+		descs[i] = f.String()
+	}
+	return strings.Join(descs, ", ")
+}
+
+// countFunc returns the number of elements of s that satisfy f.
+func countFunc(s []int, f func(int) bool) int {
+	count := 0
+	for _, n := range s {
+		if f(n) {
+			count++
+		}
+	}
+	return count
+}
+
+// longestRun returns the length of the longest run of consecutive numbers in s.
+func longestRun(s []int) int {
+	sorted := slices.Clone(s)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	longest, run := 0, 0
+	for i, n := range sorted {
+		if i > 0 && n == sorted[i-1]+1 {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// maxGroupSize returns the size of the largest group of elements of s that have the same key.
+func maxGroupSize(s []int, key func(int) int) int {
+	sizes := make(map[int]int, len(s))
+	largest := 0
+	for _, n := range s {
+		k := key(n)
+		sizes[k]++
+		largest = max(largest, sizes[k])
+	}
+	return largest
+}
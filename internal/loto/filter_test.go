@@ -0,0 +1,241 @@
+package loto_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestParseRange tests the ParseRange function
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    loto.Range
+		wantErr bool
+	}{
+		{input: "100-160", want: loto.Range{Min: 100, Max: 160}},
+		{input: " 2 - 4 ", want: loto.Range{Min: 2, Max: 4}},
+		{input: "3", want: loto.Range{Min: 3, Max: 3}},
+		{input: "160-100", wantErr: true},
+		{input: "a-b", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := loto.ParseRange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFilters tests the Accept method of each filter
+func TestFilters(t *testing.T) {
+	config, _ := loto.Lookup(loto.LOTO_6)
+
+	tests := []struct {
+		name   string
+		filter loto.Filter
+		ticket []int
+		want   bool
+	}{
+		{name: "sum in range", filter: loto.SumFilter(loto.Range{Min: 100, Max: 160}), ticket: []int{5, 12, 19, 23, 31, 40}, want: true},
+		{name: "sum out of range", filter: loto.SumFilter(loto.Range{Min: 100, Max: 160}), ticket: []int{1, 2, 3, 4, 5, 6}, want: false},
+		{name: "odd matches", filter: loto.OddFilter(loto.Range{Min: 3, Max: 3}), ticket: []int{1, 2, 3, 4, 5, 6}, want: true},
+		{name: "odd does not match", filter: loto.OddFilter(loto.Range{Min: 3, Max: 3}), ticket: []int{1, 3, 5, 7, 9, 10}, want: false},
+		{name: "high matches", filter: loto.HighFilter(loto.Range{Min: 2, Max: 4}), ticket: []int{1, 2, 22, 23, 30, 43}, want: true},
+		{name: "high does not match", filter: loto.HighFilter(loto.Range{Min: 2, Max: 4}), ticket: []int{1, 2, 3, 4, 5, 23}, want: false},
+		{name: "consecutive within max", filter: loto.MaxConsecutiveFilter(2), ticket: []int{1, 2, 10, 11, 20, 30}, want: true},
+		{name: "consecutive over max", filter: loto.MaxConsecutiveFilter(2), ticket: []int{1, 2, 3, 11, 20, 30}, want: false},
+		{name: "no consecutive", filter: loto.MaxConsecutiveFilter(1), ticket: []int{1, 3, 5, 7, 9, 11}, want: true},
+		{name: "decade within max", filter: loto.MaxSameDecadeFilter(2), ticket: []int{1, 9, 10, 19, 20, 30}, want: true},
+		{name: "decade over max", filter: loto.MaxSameDecadeFilter(2), ticket: []int{10, 11, 12, 20, 30, 40}, want: false},
+		{name: "last digit within max", filter: loto.MaxSameLastDigitFilter(1), ticket: []int{1, 2, 3, 4, 5, 6}, want: true},
+		{name: "last digit over max", filter: loto.MaxSameLastDigitFilter(1), ticket: []int{3, 13, 20, 31, 32, 34}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Accept(config, tt.ticket); got != tt.want {
+				t.Errorf("%s Accept(%v) = %v, want %v", tt.filter, tt.ticket, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLotteryGame_Filters tests that every picked ticket passes the filters
+func TestLotteryGame_Filters(t *testing.T) {
+	filters := []loto.Filter{
+		loto.SumFilter(loto.Range{Min: 100, Max: 160}),
+		loto.OddFilter(loto.Range{Min: 3, Max: 3}),
+		loto.MaxConsecutiveFilter(2),
+	}
+	lottery, err := loto.NewLottery(loto.LOTO_6, loto.WithSeed(1), loto.WithFilters(filters...))
+	if err != nil {
+		t.Fatalf("NewLottery() error = %v", err)
+	}
+	config, _ := loto.Lookup(loto.LOTO_6)
+
+	results, err := lottery.PickN(20)
+	if err != nil {
+		t.Fatalf("PickN() error = %v", err)
+	}
+	for _, result := range results {
+		for _, f := range filters {
+			if !f.Accept(config, result) {
+				t.Errorf("PickN() result %v does not pass %s", result, f)
+			}
+		}
+	}
+}
+
+// TestLotteryGame_Filters_Unsatisfiable tests that picking gives up on filters that cannot be satisfied
+func TestLotteryGame_Filters_Unsatisfiable(t *testing.T) {
+	t.Run("no ticket passes", func(t *testing.T) {
+		lottery, err := loto.NewLottery(loto.LOTO_6,
+			loto.WithFilters(loto.SumFilter(loto.Range{Min: 1, Max: 20})),
+			loto.WithMaxAttempts(1000),
+		)
+		if err != nil {
+			t.Fatalf("NewLottery() error = %v", err)
+		}
+		if _, err := lottery.PickN(1); !errors.Is(err, loto.ErrUnsatisfiable) {
+			t.Errorf("PickN() error = %v, want %v", err, loto.ErrUnsatisfiable)
+		}
+	})
+
+	t.Run("not enough unique tickets pass", func(t *testing.T) {
+		// 1+2+3+4+5+6 and 1+2+3+4+5+7 are the only tickets with a sum of 21 or 22
+		lottery, err := loto.NewLottery(loto.LOTO_6,
+			loto.WithInclude(1, 2, 3, 4, 5),
+			loto.WithFilters(loto.SumFilter(loto.Range{Min: 21, Max: 22})),
+			loto.WithMaxAttempts(1000),
+		)
+		if err != nil {
+			t.Fatalf("NewLottery() error = %v", err)
+		}
+		if _, err := lottery.PickN(3); !errors.Is(err, loto.ErrExhausted) {
+			t.Errorf("PickN() error = %v, want %v", err, loto.ErrExhausted)
+		}
+	})
+
+	t.Run("numbers result space is exact", func(t *testing.T) {
+		// 999 is the only ticket with a digit sum of 27
+		lottery, err := loto.NewLottery(loto.NUMBERS_3, loto.WithFilters(loto.SumFilter(loto.Range{Min: 27, Max: 27})))
+		if err != nil {
+			t.Fatalf("NewLottery() error = %v", err)
+		}
+		if got := lottery.ResultSpace().String(); got != "1" {
+			t.Errorf("ResultSpace() = %v, want 1", got)
+		}
+		if _, err := loto.NewLottery(loto.NUMBERS_3, loto.WithBet(loto.BOX), loto.WithFilters(loto.SumFilter(loto.Range{Min: 27, Max: 27}))); err == nil {
+			t.Error("NewLottery() error = nil, want error for a box of 999")
+		}
+	})
+}
+
+// TestNumbersFilters tests the Accept method of each numbers filter
+func TestNumbersFilters(t *testing.T) {
+	config, _ := loto.Lookup(loto.NUMBERS_4)
+
+	tests := []struct {
+		name   string
+		filter loto.Filter
+		ticket []int
+		want   bool
+	}{
+		{name: "not a repdigit", filter: loto.NoRepdigitFilter(), ticket: []int{7, 7, 7, 1}, want: true},
+		{name: "repdigit", filter: loto.NoRepdigitFilter(), ticket: []int{7, 7, 7, 7}, want: false},
+		{name: "double required", filter: loto.DoubleFilter(true), ticket: []int{1, 1, 2, 3}, want: true},
+		{name: "double-double required", filter: loto.DoubleFilter(true), ticket: []int{1, 1, 2, 2}, want: true},
+		{name: "triple is not a double", filter: loto.DoubleFilter(true), ticket: []int{1, 1, 1, 2}, want: false},
+		{name: "double forbidden", filter: loto.DoubleFilter(false), ticket: []int{1, 1, 2, 3}, want: false},
+		{name: "single without double", filter: loto.DoubleFilter(false), ticket: []int{1, 2, 3, 4}, want: true},
+		{name: "box type matches", filter: loto.BoxTypeFilter("single"), ticket: []int{1, 2, 3, 4}, want: true},
+		{name: "box type matches one of", filter: loto.BoxTypeFilter("single", "double"), ticket: []int{2, 1, 2, 4}, want: true},
+		{name: "box type does not match", filter: loto.BoxTypeFilter("single"), ticket: []int{1, 1, 3, 4}, want: false},
+		{name: "digit at position", filter: loto.DigitAtFilter(1, 7), ticket: []int{7, 0, 1, 2}, want: true},
+		{name: "digit at other position", filter: loto.DigitAtFilter(2, 7), ticket: []int{7, 0, 1, 2}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Accept(config, tt.ticket); got != tt.want {
+				t.Errorf("%s Accept(%v) = %v, want %v", tt.filter, tt.ticket, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLotteryGame_NumbersFilters tests numbers games picked with the numbers filters
+func TestLotteryGame_NumbersFilters(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []loto.Option
+		wantSpace string
+		wantErr   bool
+	}{
+		{
+			name:      "no repdigit",
+			opts:      []loto.Option{loto.WithFilters(loto.NoRepdigitFilter())},
+			wantSpace: "990",
+		},
+		{
+			name:      "6-way box",
+			opts:      []loto.Option{loto.WithBet(loto.BOX), loto.WithFilters(loto.BoxTypeFilter("single"))},
+			wantSpace: "120",
+		},
+		{
+			name:      "double with a fixed digit",
+			opts:      []loto.Option{loto.WithFilters(loto.DoubleFilter(true), loto.DigitAtFilter(1, 7))},
+			wantSpace: "27", // 77x, 7x7 and 7xx with x != 7
+		},
+		{
+			name:      "digit sum",
+			opts:      []loto.Option{loto.WithBet(loto.MINI), loto.WithFilters(loto.SumFilter(loto.Range{Min: 17, Max: 18}))},
+			wantSpace: "3", // 89, 98 and 99
+		},
+		{
+			name:    "position of a box",
+			opts:    []loto.Option{loto.WithBet(loto.BOX), loto.WithFilters(loto.DigitAtFilter(1, 7))},
+			wantErr: true,
+		},
+		{
+			name:    "position out of range",
+			opts:    []loto.Option{loto.WithBet(loto.MINI), loto.WithFilters(loto.DigitAtFilter(3, 7))},
+			wantErr: true,
+		},
+		{
+			name:    "impossible box type",
+			opts:    []loto.Option{loto.WithFilters(loto.BoxTypeFilter("quadruple"))},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lottery, err := loto.NewLottery(loto.NUMBERS_3, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewLottery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := lottery.ResultSpace().String(); got != tt.wantSpace {
+				t.Errorf("ResultSpace() = %v, want %v", got, tt.wantSpace)
+			}
+		})
+	}
+
+	t.Run("loto games", func(t *testing.T) {
+		if _, err := loto.NewLottery(loto.LOTO_6, loto.WithFilters(loto.NoRepdigitFilter())); err == nil {
+			t.Error("NewLottery() error = nil, want error")
+		}
+	})
+}
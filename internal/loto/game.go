@@ -1,66 +1,599 @@
 package loto
 
-import "slices"
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kawana77b/loto/internal/util"
+)
 
 // Lottery is an interface for lottery games.
 type Lottery interface {
 	// Perform a single random draw and obtain the result.
-	Pick() []int
+	Pick() ([]int, error)
 	// Perform multiple random draws and obtain the results.
-	PickN(count int) [][]int
+	PickN(count int) ([][]int, error)
+	// Number of distinct results that Pick can return.
+	ResultSpace() *big.Int
 }
 
+var (
+	// ErrExhausted is returned when more unique results are requested than a lottery can produce.
+	ErrExhausted = errors.New("not enough unique results")
+	// ErrUnsatisfiable is returned when no ticket passing the filters is found.
+	ErrUnsatisfiable = errors.New("no ticket satisfies the filters")
+)
+
+// DefaultMaxAttempts is the default number of draws after which picking gives up.
+const DefaultMaxAttempts = 100000
+
 // LotteryGame is a generic lottery game implementation that works for all lottery types.
 type LotteryGame struct {
-	config LotteryConfig
-	box    *Box
+	config  LotteryConfig
+	boxes   []*Box // Boxes that draws are taken from (see drawBoxes)
+	pool    *Box   // Box of the numbers that tickets are picked from
+	pools   []*Box // Box of each position that tickets are picked from (positional games only)
+	src     rand.Source
+	bet     BetType
+	capped  bool
+	include []int
+	exclude []int
+	filters []Filter
+	weights Weights
+	// Number of draws after which picking gives up
+	maxAttempts int
+	// Strategy that PickN generates the tickets with
+	strategy Strategy
+	// Most numbers two tickets picked by PickN may share (Count if unlimited)
+	maxOverlap int
+}
+
+// Option configures a LotteryGame.
+type Option func(*LotteryGame)
+
+// WithSource sets the random source used for every pick.
+// A nil source means the global random source.
+func WithSource(src rand.Source) Option {
+	return func(l *LotteryGame) {
+		l.src = src
+	}
+}
+
+// WithSeed makes every pick reproducible from the given seed.
+func WithSeed(seed uint64) Option {
+	return WithSource(util.NewSeededSource(seed))
+}
+
+// WithSecure draws every pick from crypto/rand so that the results cannot be predicted.
+func WithSecure() Option {
+	return WithSource(util.CryptoSource{})
+}
+
+// WithBet sets the bet type of the tickets picked for numbers games (STRAIGHT by default).
+// Box and set tickets are never repdigits, and box tickets are sorted in ascending order
+// because the order of their digits does not matter.
+func WithBet(bet BetType) Option {
+	return func(l *LotteryGame) {
+		l.bet = bet
+	}
+}
+
+// WithCap makes PickN return every possible result instead of failing
+// when more unique results are requested than the game can produce.
+func WithCap() Option {
+	return func(l *LotteryGame) {
+		l.capped = true
+	}
+}
+
+// WithInclude makes every picked ticket contain the given numbers.
+// Loto tickets are seeded with them and only the remaining numbers are drawn.
+// Numbers tickets must contain each given digit (as often as it is given) in any position.
+// Positional tickets have each given number at the position whose range contains it.
+func WithInclude(nums ...int) Option {
+	return func(l *LotteryGame) {
+		l.include = append(l.include, nums...)
+	}
+}
+
+// WithExclude removes the given numbers from the box that tickets are picked from,
+// so that no picked ticket contains them. Draws are not affected.
+func WithExclude(nums ...int) Option {
+	return func(l *LotteryGame) {
+		l.exclude = append(l.exclude, nums...)
+	}
+}
+
+// WithFilters makes every picked ticket pass the given filters.
+// Tickets that do not pass are drawn again, up to the maximum number of attempts.
+func WithFilters(filters ...Filter) Option {
+	return func(l *LotteryGame) {
+		l.filters = append(l.filters, filters...)
+	}
+}
+
+// WithMaxAttempts sets the number of draws after which picking gives up (DefaultMaxAttempts by default).
+// Pick gives up after n draws that do not pass the filters,
+// and PickN gives up after n draws in a row that do not give a new unique result.
+func WithMaxAttempts(n int) Option {
+	return func(l *LotteryGame) {
+		l.maxAttempts = n
+	}
+}
+
+// WithWeights makes tickets pick each number with a probability proportional to its weight.
+// Loto numbers are drawn without replacement and numbers digits with replacement.
+// Draws are not affected.
+func WithWeights(weights Weights) Option {
+	return func(l *LotteryGame) {
+		l.weights = weights
+	}
 }
 
 // NewLottery creates a new lottery game based on the given lottery type.
-func NewLottery(t LotteryType) *LotteryGame {
-	config, ok := LotteryConfigs[t]
+// It returns an error if the type is not registered or the options are not valid for it.
+// Jumbo types do not pick numbers and are created with NewJumbo instead.
+func NewLottery(t LotteryType, opts ...Option) (*LotteryGame, error) {
+	config, ok := Lookup(t)
 	if !ok {
-		return nil
+		return nil, fmt.Errorf("invalid lottery type: %s", t)
+	}
+	if config.Category == JUMBO {
+		return nil, fmt.Errorf("%s is a jumbo game, whose tickets are not picked from numbers", t)
+	}
+	l := &LotteryGame{
+		config:      config,
+		maxAttempts: DefaultMaxAttempts,
+		maxOverlap:  config.Count,
+	}
+	if config.Category == NUMBERS {
+		l.bet = STRAIGHT
+	}
+	for _, opt := range opts {
+		opt(l)
 	}
-	return &LotteryGame{
-		config: config,
-		box:    NewBox(config.Min, config.Max),
+	if l.bet != "" {
+		if err := l.bet.Validate(config); err != nil {
+			return nil, err
+		}
+	}
+	l.boxes = drawBoxes(config, l.src)
+	l.pool = NewBox(config.Min, config.Max, WithBoxSource(l.src), WithBoxWeights(l.weights))
+	l.pool.Remove(l.exclude...)
+	if !config.AllowDuplicate {
+		l.pool.Remove(l.include...)
+	}
+	for _, r := range config.Positions {
+		pool := NewBox(r.Min, r.Max, WithBoxSource(l.src), WithBoxWeights(l.weights))
+		pool.Remove(l.exclude...)
+		if included := slices.DeleteFunc(slices.Clone(l.include), func(n int) bool { return !r.Contains(n) }); len(included) > 0 {
+			// The included number is the only choice of its position
+			pool.Clear()
+			pool.Append(included...)
+		}
+		l.pools = append(l.pools, pool)
+	}
+	if err := l.validateConstraints(); err != nil {
+		return nil, err
 	}
+	return l, nil
 }
 
-// Pick performs a single random draw and returns the result.
-// For loto types (non-duplicate), the result is sorted in ascending order.
-// For numbers types (duplicate allowed), the result is returned as-is.
-func (l *LotteryGame) Pick() []int {
-	var result []int
+// validateConstraints checks that tickets can be picked with the included and excluded numbers and the filters.
+func (l *LotteryGame) validateConstraints() error {
+	for _, n := range slices.Concat(l.include, l.exclude) {
+		if n < l.config.Min || n > l.config.Max {
+			return fmt.Errorf("number out of range: %d. It must be between %d and %d", n, l.config.Min, l.config.Max)
+		}
+	}
+	for _, n := range l.include {
+		if slices.Contains(l.exclude, n) {
+			return fmt.Errorf("number both included and excluded: %d", n)
+		}
+	}
+
 	if l.config.AllowDuplicate {
-		// Numbers: return as-is (no sorting)
-		result = l.box.PickDupN(l.config.Count)
+		if digits := l.bet.Digits(l.config); len(l.include) > digits {
+			return fmt.Errorf("too many included numbers: %d. A ticket has only %d digits", len(l.include), digits)
+		}
+	} else if l.config.Positional() {
+		for i, n := range l.include {
+			if slices.Contains(l.include[:i], n) {
+				return fmt.Errorf("duplicate included number: %d", n)
+			}
+		}
+		for i, pool := range l.pools {
+			if pool.Length() == 0 {
+				return fmt.Errorf("too many excluded numbers: no number is left at position %d", i+1)
+			}
+			if pool.Length() > 1 && slices.ContainsFunc(l.include, pool.Contains) {
+				return fmt.Errorf("too many included numbers at position %d: %s. A position has only one number", i+1, FormatNumbers(l.config.Category, pool.items))
+			}
+		}
 	} else {
-		// Loto: sort the result
-		result = l.box.PickN(l.config.Count)
-		slices.Sort(result)
+		for i, n := range l.include {
+			if slices.Contains(l.include[:i], n) {
+				return fmt.Errorf("duplicate included number: %d", n)
+			}
+		}
+		if len(l.include) > l.config.Count {
+			return fmt.Errorf("too many included numbers: %d. A ticket has only %d numbers", len(l.include), l.config.Count)
+		}
+		if l.pool.Length() < l.config.Count-len(l.include) {
+			return fmt.Errorf("too many excluded numbers: only %d numbers are left for %d picks", l.pool.Length(), l.config.Count-len(l.include))
+		}
+	}
+
+	for _, f := range l.filters {
+		if err := f.Validate(l.config, l.bet); err != nil {
+			return err
+		}
+	}
+	if err := l.weights.Validate(l.config); err != nil {
+		return err
+	}
+	if l.maxAttempts <= 0 {
+		return fmt.Errorf("invalid maximum number of attempts: %d", l.maxAttempts)
+	}
+	if err := l.validateStrategy(); err != nil {
+		return err
+	}
+	if err := l.validateOverlap(); err != nil {
+		return err
+	}
+	if l.ResultSpace().Sign() == 0 {
+		return fmt.Errorf("no ticket satisfies the constraints")
+	}
+	return nil
+}
+
+// Config returns the configuration of the lottery type.
+func (l *LotteryGame) Config() LotteryConfig {
+	return l.config
+}
+
+// Include returns the numbers that every picked ticket must contain.
+func (l *LotteryGame) Include() []int {
+	return slices.Clone(l.include)
+}
+
+// Exclude returns the numbers that no picked ticket may contain.
+func (l *LotteryGame) Exclude() []int {
+	return slices.Clone(l.exclude)
+}
+
+// Bet returns the bet type of the picked tickets (empty for loto types).
+func (l *LotteryGame) Bet() BetType {
+	return l.bet
+}
+
+// Pick performs a single random draw and returns the result.
+// For loto types (non-duplicate), the result is sorted in ascending order.
+// For numbers types (duplicate allowed), the result is returned as-is, except for box bets.
+// Draws that do not pass the filters are discarded, and an error wrapping ErrUnsatisfiable
+// is returned if none of the attempts passes.
+func (l *LotteryGame) Pick() ([]int, error) {
+	for range l.maxAttempts {
+		var result []int
+		if l.config.AllowDuplicate {
+			// Numbers: return as-is (no sorting)
+			result = l.pool.PickDupN(l.bet.Digits(l.config))
+		} else if l.config.Positional() {
+			// Positional: one number from each position, in the order of the positions
+			// (a single pick, so with replacement instead of shuffling the whole pool)
+			result = make([]int, len(l.pools))
+			for i, pool := range l.pools {
+				result[i] = pool.PickDupN(1)[0]
+			}
+		} else {
+			// Loto: seed the included numbers and sort the result
+			result = append(l.pool.PickN(l.config.Count-len(l.include)), l.include...)
+			slices.Sort(result)
+		}
+		if !l.accepts(result) {
+			continue
+		}
+		if l.bet == BOX {
+			slices.Sort(result)
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("%w after %d attempts: %s", ErrUnsatisfiable, l.maxAttempts, describeFilters(l.filters))
+}
+
+// accepts reports whether the numbers can be picked as a ticket.
+func (l *LotteryGame) accepts(ticket []int) bool {
+	switch l.bet {
+	case BOX, SET:
+		// Repdigits cannot be played as box or set
+		if !ClassifyBox(ticket).Boxable() {
+			return false
+		}
+	}
+	if !containsAll(ticket, l.include) {
+		return false
+	}
+	for _, f := range l.filters {
+		if !f.Accept(l.config, ticket) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAll reports whether s contains every element of sub, as often as it appears in sub.
+func containsAll(s, sub []int) bool {
+	counts := make(map[int]int, len(s))
+	for _, n := range s {
+		counts[n]++
+	}
+	for _, n := range sub {
+		if counts[n] == 0 {
+			return false
+		}
+		counts[n]--
+	}
+	return true
+}
+
+// Draw holds the winning numbers of an official draw.
+type Draw struct {
+	Numbers []int // Main numbers
+	Bonus   []int // Bonus numbers (empty if the lottery has none)
+}
+
+// Draw performs a simulated official draw.
+// The bonus numbers are taken from the same box as the main numbers without replacement,
+// so they never overlap. Both the main and bonus numbers of loto types are sorted in ascending order.
+// Positional games draw one number from each position, in the order of the positions.
+func (l *LotteryGame) Draw() Draw {
+	return drawFrom(l.config, l.boxes)
+}
+
+// drawBoxes returns the boxes that the draws of the lottery are taken from:
+// a box for each position of positional games, or a single box of every number.
+func drawBoxes(config LotteryConfig, src rand.Source) []*Box {
+	if !config.Positional() {
+		return []*Box{NewBox(config.Min, config.Max, WithBoxSource(src))}
+	}
+	boxes := make([]*Box, len(config.Positions))
+	for i, r := range config.Positions {
+		boxes[i] = NewBox(r.Min, r.Max, WithBoxSource(src))
+	}
+	return boxes
+}
+
+// drawFrom performs a simulated official draw of the lottery from the boxes given by drawBoxes.
+func drawFrom(config LotteryConfig, boxes []*Box) Draw {
+	if config.Positional() {
+		numbers := make([]int, len(boxes))
+		for i, box := range boxes {
+			numbers[i] = box.PickDupN(1)[0]
+		}
+		return Draw{
+			Numbers: numbers,
+			Bonus:   []int{},
+		}
+	}
+
+	box := boxes[0]
+	if config.AllowDuplicate {
+		// Numbers: no bonus numbers
+		return Draw{
+			Numbers: box.PickDupN(config.Count),
+			Bonus:   []int{},
+		}
+	}
+
+	picked := box.PickN(config.Count + config.Bonus)
+	numbers := picked[:config.Count]
+	bonus := picked[config.Count:]
+	slices.Sort(numbers)
+	slices.Sort(bonus)
+	return Draw{
+		Numbers: numbers,
+		Bonus:   bonus,
 	}
-	return result
+}
+
+// ResultSpace returns the number of distinct results that Pick can return.
+// Loto types count combinations of the numbers left after the included and excluded ones.
+// The filters are not taken into account, so it is only an upper bound for filtered loto types.
+// Positional games multiply the numbers left at each position, also without the filters.
+// Numbers types count the permutations with repetition of the digits played by the bet,
+// without repdigits for box and set bets and regardless of order for box bets.
+func (l *LotteryGame) ResultSpace() *big.Int {
+	if l.config.Positional() {
+		space := big.NewInt(1)
+		for _, pool := range l.pools {
+			space.Mul(space, big.NewInt(int64(pool.Length())))
+		}
+		return space
+	}
+
+	n := int64(l.pool.Length())
+	if !l.config.AllowDuplicate {
+		return new(big.Int).Binomial(n, int64(l.config.Count-len(l.include)))
+	}
+	if len(l.include) > 0 || len(l.filters) > 0 {
+		return big.NewInt(int64(l.countTickets()))
+	}
+
+	digits := int64(l.bet.Digits(l.config))
+	space := new(big.Int)
+	switch l.bet {
+	case BOX:
+		// Multisets of the digits, except the repdigits
+		space.Binomial(n+digits-1, digits)
+		space.Sub(space, big.NewInt(n))
+	case SET:
+		// Sequences of the digits, except the repdigits
+		space.Exp(big.NewInt(n), big.NewInt(digits), nil)
+		space.Sub(space, big.NewInt(n))
+	default:
+		space.Exp(big.NewInt(n), big.NewInt(digits), nil)
+	}
+	return space
+}
+
+// countTickets counts the tickets that can be picked by going through every one of them.
+func (l *LotteryGame) countTickets() int {
+	count := 0
+	l.eachTicket(func([]int) { count++ })
+	return count
+}
+
+// eachTicket calls fn with every ticket that can be picked, with the included and excluded numbers,
+// the bet type and the filters taken into account. The ticket is reused between calls.
+// Loto tickets are sorted, numbers tickets go through every sequence of the digits
+// (only sequences in ascending order for box bets, which are sorted), and positional tickets
+// go through every number of each position.
+func (l *LotteryGame) eachTicket(fn func(ticket []int)) {
+	var ticket []int
+	var choices func(pos, from int) []int
+	switch {
+	case l.config.AllowDuplicate:
+		digits := slices.Clone(l.pool.items)
+		slices.Sort(digits)
+		ticket = make([]int, l.bet.Digits(l.config))
+		choices = func(pos, from int) []int { return digits[from:] }
+	case l.config.Positional():
+		ticket = make([]int, len(l.pools))
+		choices = func(pos, _ int) []int { return l.pools[pos].items }
+	default:
+		numbers := slices.Clone(l.pool.items)
+		slices.Sort(numbers)
+		ticket = make([]int, l.config.Count-len(l.include))
+		choices = func(pos, from int) []int { return numbers[from:] }
+	}
+
+	var walk func(pos, from int)
+	walk = func(pos, from int) {
+		if pos == len(ticket) {
+			result := ticket
+			if !l.config.AllowDuplicate && !l.config.Positional() {
+				result = append(slices.Clone(ticket), l.include...)
+				slices.Sort(result)
+			}
+			if l.accepts(result) {
+				fn(result)
+			}
+			return
+		}
+		for i, n := range choices(pos, from) {
+			ticket[pos] = n
+			next := 0
+			switch {
+			case l.bet == BOX:
+				next = from + i
+			case !l.config.AllowDuplicate && !l.config.Positional():
+				next = from + i + 1
+			}
+			walk(pos+1, next)
+		}
+	}
+	walk(0, 0)
 }
 
 // PickN performs multiple random draws and returns the results.
-func (l *LotteryGame) PickN(count int) [][]int {
-	return pickN(l, count)
+// Each result is unique. It returns an error wrapping ErrExhausted if count is larger than ResultSpace,
+// or if no new unique result is found within the maximum number of attempts.
+// If the game was created with WithCap, it returns every result it can find instead.
+// The results are generated by the strategy of the game.
+func (l *LotteryGame) PickN(count int) ([][]int, error) {
+	if l.capped {
+		if space := l.ResultSpace(); space.Cmp(big.NewInt(int64(count))) < 0 {
+			count = int(space.Int64())
+		}
+	}
+	results, err := l.strategy.PickN(l, count)
+	if verr := l.validateTickets(results); verr != nil {
+		return nil, fmt.Errorf("strategy %s: %w", l.strategy.Name(), verr)
+	}
+	if l.capped && errors.Is(err, ErrExhausted) {
+		return results, nil
+	}
+	return results, err
+}
+
+// validateTickets checks that the tickets generated by a strategy are unique, valid for the lottery
+// and satisfy the included and excluded numbers, the bet type and the filters.
+func (l *LotteryGame) validateTickets(tickets [][]int) error {
+	seen := make(map[string]bool, len(tickets))
+	for _, ticket := range tickets {
+		var err error
+		if l.config.AllowDuplicate {
+			if digits := l.bet.Digits(l.config); len(ticket) != digits {
+				err = fmt.Errorf("expected %d digits, got %d", digits, len(ticket))
+			} else {
+				err = l.config.validateRange(ticket)
+			}
+		} else {
+			err = l.config.ValidateNumbers(ticket)
+		}
+		if err == nil {
+			if i := slices.IndexFunc(ticket, func(n int) bool { return slices.Contains(l.exclude, n) }); i >= 0 {
+				err = fmt.Errorf("number %d is excluded", ticket[i])
+			} else if !l.accepts(ticket) {
+				err = fmt.Errorf("the ticket does not satisfy the included numbers, the bet type or the filters")
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("invalid ticket %v: %w", ticket, err)
+		}
+
+		key := resultKey(ticket)
+		if seen[key] {
+			return fmt.Errorf("ticket %v is picked twice", ticket)
+		}
+		seen[key] = true
+	}
+	return nil
 }
 
-// pickN performs multiple random draws from the given lottery. Each result is unique.
-func pickN(lottery Lottery, count int) [][]int {
+// pickN performs multiple random draws from the given lottery until it has count unique results.
+// It fails instead of looping forever if the lottery cannot produce that many unique results,
+// or if maxAttempts draws in a row give no new result. The results found so far are returned with ErrExhausted.
+func pickN(lottery Lottery, count int, maxAttempts int) ([][]int, error) {
+	if space := lottery.ResultSpace(); space.Cmp(big.NewInt(int64(count))) < 0 {
+		return nil, fmt.Errorf("%w: %d requested, but only %s are possible", ErrExhausted, count, space)
+	}
+
 	results := make([][]int, 0, count)
+	seen := make(map[string]struct{}, count)
+	misses := 0
 	for len(results) < count {
-		picked := lottery.Pick()
+		picked, err := lottery.Pick()
+		if err != nil {
+			return nil, err
+		}
 
 		// The result should be that each element is unique.
-		if !slices.ContainsFunc(results, func(r []int) bool {
-			return slices.Equal(picked, r)
-		}) {
-			results = append(results, picked)
+		key := resultKey(picked)
+		if _, ok := seen[key]; ok {
+			misses++
+			if misses >= maxAttempts {
+				return results, fmt.Errorf("%w: %d requested, but only %d were found", ErrExhausted, count, len(results))
+			}
+			continue
 		}
+		seen[key] = struct{}{}
+		results = append(results, picked)
+		misses = 0
+	}
+	return results, nil
+}
+
+// resultKey returns a string that identifies the result.
+func resultKey(result []int) string {
+	fields := make([]string, len(result))
+	for i, n := range result {
+		fields[i] = strconv.Itoa(n)
 	}
-	return results
+	return strings.Join(fields, ",")
 }
@@ -0,0 +1,169 @@
+package loto
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// GameDefinition is a user-defined lottery game in a games file.
+type GameDefinition struct {
+	Name           string          `json:"name" yaml:"name" toml:"name"`
+	Category       LotteryCategory `json:"category" yaml:"category" toml:"category"`
+	Count          int             `json:"count" yaml:"count" toml:"count"`
+	Min            int             `json:"min" yaml:"min" toml:"min"`
+	Max            int             `json:"max" yaml:"max" toml:"max"`
+	Positions      []Range         `json:"positions" yaml:"positions" toml:"positions"`
+	AllowDuplicate bool            `json:"allow_duplicate" yaml:"allow_duplicate" toml:"allow_duplicate"`
+	Bonus          int             `json:"bonus" yaml:"bonus" toml:"bonus"`
+	Price          int             `json:"price" yaml:"price" toml:"price"`
+	Tiers          []PrizeTier     `json:"tiers" yaml:"tiers" toml:"tiers"`
+	Schedule       *Schedule       `json:"schedule" yaml:"schedule" toml:"schedule"`
+	Groups         Range           `json:"groups" yaml:"groups" toml:"groups"`
+	Serials        Range           `json:"serials" yaml:"serials" toml:"serials"`
+}
+
+// Config returns the lottery configuration of the game.
+func (d GameDefinition) Config() LotteryConfig {
+	return LotteryConfig{
+		Category:       d.Category,
+		Count:          d.Count,
+		Min:            d.Min,
+		Max:            d.Max,
+		Positions:      d.Positions,
+		AllowDuplicate: d.AllowDuplicate,
+		Bonus:          d.Bonus,
+		Price:          d.Price,
+		Tiers:          d.Tiers,
+		Schedule:       d.Schedule,
+		Groups:         d.Groups,
+		Serials:        d.Serials,
+	}
+}
+
+// gamesFile is the layout of a games file.
+type gamesFile struct {
+	Games     []GameDefinition    `json:"games" yaml:"games" toml:"games"`
+	Cancelled map[string][]string `json:"cancelled" yaml:"cancelled" toml:"cancelled"` // Dates without a draw by game, for the built-in games too
+}
+
+// GamesFileNames are the file names searched for user-defined games, in order.
+var GamesFileNames = []string{"games.yaml", "games.yml", "games.toml", "games.json"}
+
+// ParseGames parses the game definitions of a games file.
+// The format is chosen by the file extension: .yaml/.yml, .toml or .json.
+//
+//	games:
+//	  - name: office5
+//	    category: loto
+//	    count: 5
+//	    min: 1
+//	    max: 50
+func ParseGames(path string, data []byte) ([]GameDefinition, error) {
+	file, err := parseGamesFile(path, data)
+	if err != nil {
+		return nil, err
+	}
+	return file.Games, nil
+}
+
+// parseGamesFile parses a games file, with the format chosen by the file extension.
+func parseGamesFile(path string, data []byte) (gamesFile, error) {
+	var file gamesFile
+	var err error
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	case ".toml":
+		err = toml.Unmarshal(data, &file)
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	default:
+		return file, fmt.Errorf("unsupported games file format: %s", ext)
+	}
+	if err != nil {
+		return file, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// LoadGames reads a games file, registers every game in it
+// and adds its cancelled dates to the schedules of the games.
+// Nothing is registered if any game or cancelled date is invalid.
+//
+//	cancelled:
+//	  loto6: [2026-05-04]
+func LoadGames(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	file, err := parseGamesFile(path, data)
+	if err != nil {
+		return err
+	}
+	games := file.Games
+
+	// Validate every game before registering any of them
+	seen := make(map[string]bool, len(games))
+	for _, game := range games {
+		if err := validateGameName(game.Name); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if seen[game.Name] {
+			return fmt.Errorf("%s: game %s is defined more than once", path, game.Name)
+		}
+		seen[game.Name] = true
+		if _, ok := Lookup(LotteryType(game.Name)); ok {
+			return fmt.Errorf("%s: game %s is already defined", path, game.Name)
+		}
+		if err := game.Config().Validate(); err != nil {
+			return fmt.Errorf("%s: game %s: %w", path, game.Name, err)
+		}
+	}
+	names := slices.Sorted(maps.Keys(file.Cancelled))
+	for _, name := range names {
+		schedule := cancelledSchedule(LotteryType(name), games)
+		if schedule == nil {
+			return fmt.Errorf("%s: cancelled draws of game %s, which has no schedule", path, name)
+		}
+		schedule.Cancelled = file.Cancelled[name]
+		if _, err := schedule.cancelled(); err != nil {
+			return fmt.Errorf("%s: game %s: %w", path, name, err)
+		}
+	}
+
+	for _, game := range games {
+		if err := Register(LotteryType(game.Name), game.Config()); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for _, name := range names {
+		if err := Cancel(LotteryType(name), file.Cancelled[name]...); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// cancelledSchedule returns a copy of the schedule of the game, defined in the games or registered, or nil if it has none.
+func cancelledSchedule(t LotteryType, games []GameDefinition) *Schedule {
+	for _, game := range games {
+		if LotteryType(game.Name) == t && game.Schedule != nil {
+			schedule := *game.Schedule
+			return &schedule
+		}
+	}
+	if config, ok := Lookup(t); ok && config.Schedule != nil {
+		schedule := *config.Schedule
+		return &schedule
+	}
+	return nil
+}
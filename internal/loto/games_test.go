@@ -0,0 +1,164 @@
+package loto_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestLoadGames tests loading user-defined games from every supported file format
+func TestLoadGames(t *testing.T) {
+	files := map[string]string{
+		"games.yaml": `
+games:
+  - name: yaml5
+    category: loto
+    count: 5
+    min: 1
+    max: 50
+    bonus: 1
+    price: 100
+    tiers:
+      - {rank: 1, main: 5}
+      - {rank: 2, main: 4, bonus: 1}
+`,
+		"games.toml": `
+[[games]]
+name = "toml5"
+category = "loto"
+count = 5
+min = 1
+max = 50
+bonus = 1
+price = 100
+
+[[games.tiers]]
+rank = 1
+main = 5
+
+[[games.tiers]]
+rank = 2
+main = 4
+bonus = 1
+`,
+		"games.json": `{"games": [{"name": "json5", "category": "loto", "count": 5, "min": 1, "max": 50, "bonus": 1, "price": 100,
+			"tiers": [{"rank": 1, "main": 5}, {"rank": 2, "main": 4, "bonus": 1}]}]}`,
+	}
+
+	for file, content := range files {
+		t.Run(file, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), file)
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := loto.LoadGames(path); err != nil {
+				t.Fatalf("LoadGames() error = %v", err)
+			}
+
+			name := file[len("games."):] + "5"
+			lotteryType := loto.LotteryType(name)
+			if err := lotteryType.Validate(); err != nil {
+				t.Errorf("LotteryType.Validate() error = %v", err)
+			}
+			if config, _ := loto.Lookup(lotteryType); config.Price != 100 {
+				t.Errorf("Lookup() price = %v, want 100", config.Price)
+			}
+			if got := loto.GetCategory(lotteryType); got != loto.LOTO {
+				t.Errorf("GetCategory() = %v, want %v", got, loto.LOTO)
+			}
+
+			lottery, err := loto.NewLottery(lotteryType)
+			if err != nil {
+				t.Fatalf("NewLottery() error = %v", err)
+			}
+			if result, err := lottery.Pick(); err != nil || len(result) != 5 {
+				t.Errorf("Pick() = %v, %v, want 5 numbers", result, err)
+			}
+			result, err := loto.Check(lotteryType, []int{1, 2, 3, 4, 6}, loto.Draw{Numbers: []int{1, 2, 3, 4, 5}, Bonus: []int{6}})
+			if err != nil || !result.Won() || result.Tier.Rank != 2 {
+				t.Errorf("Check() = %+v, %v, want 2nd prize", result, err)
+			}
+		})
+	}
+}
+
+// TestLoadGames_Invalid tests that invalid games files register nothing
+func TestLoadGames_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{"min greater than max", "games.yaml", "games:\n  - {name: invalid1, category: loto, count: 5, min: 50, max: 1}\n"},
+		{"count larger than range", "games.yaml", "games:\n  - {name: invalid2, category: loto, count: 6, min: 1, max: 5}\n"},
+		{"non-positive count", "games.yaml", "games:\n  - {name: invalid3, category: loto, count: 0, min: 1, max: 5}\n"},
+		{"unknown category", "games.yaml", "games:\n  - {name: invalid4, category: keno, count: 5, min: 1, max: 50}\n"},
+		{"tier with too many matches", "games.yaml", "games:\n  - {name: invalid5, category: loto, count: 5, min: 1, max: 50, tiers: [{rank: 1, main: 6}]}\n"},
+		{"negative prize", "games.yaml", "games:\n  - {name: invalid9, category: loto, count: 5, min: 1, max: 50, tiers: [{rank: 1, main: 5, prize: -1}]}\n"},
+		{"invalid schedule", "games.yaml", "games:\n  - {name: invalid10, category: loto, count: 5, min: 1, max: 50, schedule: {weekdays: [someday], draw_time: \"18:45\", sales_close: \"18:30\", reference: {number: 1, date: 2024-01-04}}}\n"},
+		{"overlapping positions", "games.yaml", "games:\n  - {name: invalid11, category: loto, count: 2, min: 1, max: 10, positions: [{min: 1, max: 5}, {min: 5, max: 10}]}\n"},
+		{"descending positions", "games.yaml", "games:\n  - {name: invalid19, category: loto, count: 2, min: 1, max: 10, positions: [{min: 6, max: 10}, {min: 1, max: 5}]}\n"},
+		{"jumbo groups from 0", "games.yaml", "games:\n  - {name: invalid20, category: jumbo, groups: {min: 0, max: 9}, serials: {min: 1000, max: 1999}}\n"},
+		{"position out of range", "games.yaml", "games:\n  - {name: invalid12, category: loto, count: 2, min: 1, max: 10, positions: [{min: 1, max: 5}, {min: 6, max: 11}]}\n"},
+		{"positions of a numbers game", "games.yaml", "games:\n  - {name: invalid13, category: numbers, count: 2, min: 0, max: 9, allow_duplicate: true, positions: [{min: 0, max: 4}, {min: 5, max: 9}]}\n"},
+		{"bingo without positions", "games.yaml", "games:\n  - {name: invalid14, category: bingo, count: 8, min: 1, max: 40}\n"},
+		{"lines of a loto tier", "games.yaml", "games:\n  - {name: invalid15, category: loto, count: 5, min: 1, max: 50, tiers: [{rank: 1, main: 5, lines: 1}]}\n"},
+		{"jumbo with positions", "games.yaml", "games:\n  - {name: invalid16, category: jumbo, count: 2, min: 1, max: 1999, positions: [{min: 1, max: 9}, {min: 1000, max: 1999}]}\n"},
+		{"jumbo serial numbers of partial tens", "games.yaml", "games:\n  - {name: invalid17, category: jumbo, groups: {min: 1, max: 9}, serials: {min: 1000, max: 1995}}\n"},
+		{"jumbo with prize tiers", "games.yaml", "games:\n  - {name: invalid18, category: jumbo, groups: {min: 1, max: 9}, serials: {min: 1000, max: 1999}, tiers: [{rank: 1, main: 2}]}\n"},
+		{"groups of a loto game", "games.yaml", "games:\n  - {name: invalid21, category: loto, count: 5, min: 1, max: 50, groups: {min: 1, max: 9}}\n"},
+		{"cancelled draws of a game without a schedule", "games.yaml", "cancelled:\n  jumbo: [2026-05-04]\n"},
+		{"cancelled draws of an unknown game", "games.yaml", "cancelled:\n  keno: [2026-05-04]\n"},
+		{"invalid cancelled date", "games.yaml", "cancelled:\n  loto6: [2026/05/04]\n"},
+		{"built-in name", "games.yaml", "games:\n  - {name: loto6, category: loto, count: 5, min: 1, max: 50}\n"},
+		{"name with spaces", "games.yaml", "games:\n  - {name: office pool, category: loto, count: 5, min: 1, max: 50}\n"},
+		{"unsupported format", "games.ini", "[games]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := loto.LoadGames(path); err == nil {
+				t.Error("LoadGames() error = nil, want error")
+			}
+		})
+	}
+}
+
+// TestLoadGames_Cancelled tests that the cancelled dates of a games file are added to the schedules
+func TestLoadGames_Cancelled(t *testing.T) {
+	content := `
+games:
+  - name: cancelled5
+    category: loto
+    count: 5
+    min: 1
+    max: 50
+    schedule:
+      weekdays: [wed]
+      draw_time: "12:00"
+      sales_close: "11:30"
+      reference: { number: 1, date: 2024-01-10 }
+cancelled:
+  cancelled5: [2024-01-17]
+`
+	path := filepath.Join(t.TempDir(), "games.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loto.LoadGames(path); err != nil {
+		t.Fatalf("LoadGames() error = %v", err)
+	}
+
+	config, _ := loto.Lookup("cancelled5")
+	draws := config.Schedule.Next(time.Date(2024, 1, 11, 0, 0, 0, 0, loto.JST), 1)
+	if draws[0].Number != 2 || draws[0].Time.Format(time.DateOnly) != "2024-01-24" {
+		t.Errorf("Next() = %v, want draw 2 on 2024-01-24", draws)
+	}
+}
@@ -0,0 +1,252 @@
+package loto
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/kawana77b/loto/internal/util"
+	"gopkg.in/yaml.v3"
+)
+
+// PackSize is the number of tickets in a pack of jumbo tickets.
+const PackSize = 10
+
+// JumboTicket is a ticket of a jumbo game, identified by its group (組) and its serial number (番号).
+type JumboTicket struct {
+	Group  int `json:"group" yaml:"group"`
+	Number int `json:"number" yaml:"number"`
+}
+
+// String returns the ticket as it is printed (e.g., "07組 123456番").
+func (t JumboTicket) String() string {
+	return fmt.Sprintf("%02d組 %06d番", t.Group, t.Number)
+}
+
+// MarshalYAML encodes the ticket as a flow mapping (e.g., {group: 7, number: 123456}).
+func (t JumboTicket) MarshalYAML() (any, error) {
+	node := &yaml.Node{}
+	if err := node.Encode(struct {
+		Group  int `yaml:"group"`
+		Number int `yaml:"number"`
+	}(t)); err != nil {
+		return nil, err
+	}
+	node.Style = yaml.FlowStyle
+	return node, nil
+}
+
+// sameTen reports whether the tickets are of the same group and their serial numbers differ only in the last digit.
+func (t JumboTicket) sameTen(other JumboTicket) bool {
+	return t.Group == other.Group && t.Number/10 == other.Number/10
+}
+
+// JumboGame is a jumbo lottery game. Its tickets are not drawn from numbers but pre-numbered
+// with a group and a serial number, so it picks JumboTicket values instead of results of numbers.
+type JumboGame struct {
+	config LotteryConfig
+	src    rand.Source
+	groups []int // Groups that tickets are picked from, in ascending order
+	capped bool
+	// Number of picks after which picking packs gives up
+	maxAttempts int
+}
+
+// NewJumbo creates a new jumbo game based on the given lottery type.
+// WithInclude picks tickets only from the given groups and WithExclude never from them.
+// The random source, WithCap and WithMaxAttempts apply as for NewLottery, and the other options
+// are not available for jumbo games. It returns an error if the type is not a registered jumbo game.
+func NewJumbo(t LotteryType, opts ...Option) (*JumboGame, error) {
+	config, ok := Lookup(t)
+	if !ok {
+		return nil, fmt.Errorf("invalid lottery type: %s", t)
+	}
+	if config.Category != JUMBO {
+		return nil, fmt.Errorf("%s is not a jumbo game", t)
+	}
+
+	// The options are applied to a lottery game to take the settings that jumbo games support
+	l := &LotteryGame{config: config, maxAttempts: DefaultMaxAttempts}
+	for _, opt := range opts {
+		opt(l)
+	}
+	switch {
+	case l.bet != "":
+		return nil, fmt.Errorf("bet types are only available for numbers games")
+	case len(l.filters) > 0:
+		return nil, fmt.Errorf("filters are not available for jumbo games")
+	case len(l.weights) > 0:
+		return nil, fmt.Errorf("weights are not available for jumbo games")
+	case l.strategy != nil || l.maxOverlap != 0:
+		return nil, fmt.Errorf("strategies are not available for jumbo games")
+	case l.maxAttempts <= 0:
+		return nil, fmt.Errorf("invalid maximum number of attempts: %d", l.maxAttempts)
+	}
+
+	for _, n := range slices.Concat(l.include, l.exclude) {
+		if !config.Groups.Contains(n) {
+			return nil, fmt.Errorf("group out of range: %d. It must be between %d and %d", n, config.Groups.Min, config.Groups.Max)
+		}
+	}
+	groups := NewBox(config.Groups.Min, config.Groups.Max)
+	if len(l.include) > 0 {
+		groups.Clear()
+		groups.Append(l.include...)
+	}
+	for _, n := range l.exclude {
+		if slices.Contains(l.include, n) {
+			return nil, fmt.Errorf("group both included and excluded: %d", n)
+		}
+	}
+	groups.Remove(l.exclude...)
+	if groups.Length() == 0 {
+		return nil, fmt.Errorf("too many excluded groups: no group is left")
+	}
+	j := &JumboGame{
+		config:      config,
+		src:         l.src,
+		groups:      slices.Compact(slices.Sorted(slices.Values(groups.items))),
+		capped:      l.capped,
+		maxAttempts: l.maxAttempts,
+	}
+	return j, nil
+}
+
+// Config returns the configuration of the lottery type.
+func (j *JumboGame) Config() LotteryConfig {
+	return j.config
+}
+
+// Pick picks a single ticket, of one of the groups and any serial number.
+func (j *JumboGame) Pick() JumboTicket {
+	return j.ticketAt(util.IntN(j.src, j.space()))
+}
+
+// ResultSpace returns the number of distinct tickets that Pick can return: every serial number of every group.
+func (j *JumboGame) ResultSpace() *big.Int {
+	return big.NewInt(int64(j.space()))
+}
+
+// PickN picks count distinct tickets, the first ones of a random order of every ticket,
+// so that fewer tickets from the same seed are the first of them.
+// It returns an error wrapping ErrExhausted if count is larger than ResultSpace,
+// or every ticket instead if the game was created with WithCap.
+func (j *JumboGame) PickN(count int) ([]JumboTicket, error) {
+	space := j.space()
+	if space < count {
+		if !j.capped {
+			return nil, fmt.Errorf("%w: %d requested, but only %d are possible", ErrExhausted, count, space)
+		}
+		count = space
+	}
+	tickets := make([]JumboTicket, count)
+	for i, index := range util.Sample(j.src, space, count) {
+		tickets[i] = j.ticketAt(index)
+	}
+	return tickets, nil
+}
+
+// space returns the number of distinct tickets, as ResultSpace.
+func (j *JumboGame) space() int {
+	return len(j.groups) * j.serials()
+}
+
+// serials returns the number of serial numbers of a group.
+func (j *JumboGame) serials() int {
+	return j.config.Serials.Max - j.config.Serials.Min + 1
+}
+
+// ticketAt returns the ticket at the index of the tickets ordered by group and serial number.
+func (j *JumboGame) ticketAt(index int) JumboTicket {
+	return JumboTicket{
+		Group:  j.groups[index/j.serials()],
+		Number: j.config.Serials.Min + index%j.serials(),
+	}
+}
+
+// PackType represents how the tickets of a pack of jumbo tickets are chosen.
+type PackType string
+
+const (
+	// Pack types of jumbo tickets
+	RENBAN = PackType("renban") // 連番: the 10 consecutive serial numbers of a group ending in 0 to 9
+	BARA   = PackType("bara")   // バラ: 10 tickets of different groups and serial numbers, one ending in each digit
+)
+
+// PackTypes returns all pack types.
+func PackTypes() []PackType {
+	return []PackType{RENBAN, BARA}
+}
+
+// String returns the string representation of the pack type.
+func (p PackType) String() string {
+	return string(p)
+}
+
+// Validate checks if the pack type can be bought for the lottery.
+func (p PackType) Validate(config LotteryConfig) error {
+	if !slices.Contains(PackTypes(), p) {
+		return fmt.Errorf("invalid pack type: %s. It must be renban or bara", p)
+	}
+	if config.Category != JUMBO {
+		return fmt.Errorf("packs are only available for jumbo games")
+	}
+	return nil
+}
+
+// JumboPack is a pack of jumbo tickets, sorted by the last digit of their serial numbers.
+type JumboPack struct {
+	Type    PackType
+	Tickets []JumboTicket
+}
+
+// PickPacks picks count packs of jumbo tickets of the pack type, and no ticket is in two packs.
+// It returns an error wrapping ErrExhausted if count packs cannot be found, like PickN.
+func (j *JumboGame) PickPacks(pack PackType, count int) ([]JumboPack, error) {
+	if err := pack.Validate(j.config); err != nil {
+		return nil, err
+	}
+	if space := j.space(); space < count*PackSize {
+		return nil, fmt.Errorf("%w: %d packs requested, but only %d tickets are possible", ErrExhausted, count, space)
+	}
+
+	packs := make([]JumboPack, 0, count)
+	seen := make(map[JumboTicket]bool, count*PackSize)
+	misses := 0
+	for len(packs) < count {
+		tickets := j.pickPack(pack, seen)
+		if tickets == nil {
+			misses++
+			if misses >= j.maxAttempts {
+				return packs, fmt.Errorf("%w: %d packs requested, but only %d were found", ErrExhausted, count, len(packs))
+			}
+			continue
+		}
+		for _, t := range tickets {
+			seen[t] = true
+		}
+		packs = append(packs, JumboPack{Type: pack, Tickets: tickets})
+		misses = 0
+	}
+	return packs, nil
+}
+
+// pickPack picks the tickets of a pack, or returns nil if one of them has already been picked.
+// Renban packs take the serial numbers around a picked ticket, and bara packs pick a ticket
+// for each last digit from different tens.
+func (j *JumboGame) pickPack(pack PackType, seen map[JumboTicket]bool) []JumboTicket {
+	tickets := make([]JumboTicket, PackSize)
+	var picked JumboTicket
+	for digit := range PackSize {
+		if digit == 0 || pack == BARA {
+			picked = j.Pick()
+		}
+		ticket := JumboTicket{Group: picked.Group, Number: picked.Number - picked.Number%10 + digit}
+		if seen[ticket] || (pack == BARA && slices.ContainsFunc(tickets[:digit], ticket.sameTen)) {
+			return nil
+		}
+		tickets[digit] = ticket
+	}
+	return tickets
+}
@@ -0,0 +1,199 @@
+package loto_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+	"gopkg.in/yaml.v3"
+)
+
+// TestJumboTicket tests that jumbo tickets are formatted with their group and serial number in every format
+func TestJumboTicket(t *testing.T) {
+	ticket := loto.JumboTicket{Group: 7, Number: 123456}
+	if got := ticket.String(); got != "07組 123456番" {
+		t.Errorf("JumboTicket.String() = %q, want %q", got, "07組 123456番")
+	}
+	if got, err := json.Marshal(ticket); err != nil || string(got) != `{"group":7,"number":123456}` {
+		t.Errorf("json.Marshal() = %s, %v, want %s", got, err, `{"group":7,"number":123456}`)
+	}
+	if got, err := yaml.Marshal(ticket); err != nil || string(got) != "{group: 7, number: 123456}\n" {
+		t.Errorf("yaml.Marshal() = %q, %v, want %q", got, err, "{group: 7, number: 123456}\n")
+	}
+}
+
+// TestNewJumbo tests that jumbo games only take the options they support and groups to include or exclude
+func TestNewJumbo(t *testing.T) {
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		opts        []loto.Option
+		wantErr     bool
+	}{
+		{"jumbo", loto.JUMBO_TAKARAKUJI, nil, false},
+		{"included and excluded groups", loto.JUMBO_TAKARAKUJI, []loto.Option{loto.WithInclude(7, 8), loto.WithExclude(9)}, false},
+		{"loto6", loto.LOTO_6, nil, true},
+		{"group out of range", loto.JUMBO_TAKARAKUJI, []loto.Option{loto.WithInclude(201)}, true},
+		{"serial number", loto.JUMBO_TAKARAKUJI, []loto.Option{loto.WithInclude(123456)}, true},
+		{"group both included and excluded", loto.JUMBO_TAKARAKUJI, []loto.Option{loto.WithInclude(7), loto.WithExclude(7)}, true},
+		{"filters", loto.JUMBO_TAKARAKUJI, []loto.Option{loto.WithFilters(loto.SumFilter(loto.Range{Min: 1, Max: 100}))}, true},
+		{"bet", loto.JUMBO_TAKARAKUJI, []loto.Option{loto.WithBet(loto.STRAIGHT)}, true},
+		{"weights", loto.JUMBO_TAKARAKUJI, []loto.Option{loto.WithWeights(loto.Weights{7: 2})}, true},
+		{"spread", loto.JUMBO_TAKARAKUJI, []loto.Option{loto.WithSpread()}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loto.NewJumbo(tt.lotteryType, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewJumbo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := loto.NewLottery(loto.JUMBO_TAKARAKUJI); err == nil {
+		t.Error("NewLottery() of jumbo error = nil, want error")
+	}
+}
+
+// TestJumboGame_PickN tests that picked tickets are distinct, of the included groups and within the serial numbers,
+// and that fewer tickets from the same seed are the first of them
+func TestJumboGame_PickN(t *testing.T) {
+	config, _ := loto.Lookup(loto.JUMBO_TAKARAKUJI)
+	jumbo, err := loto.NewJumbo(loto.JUMBO_TAKARAKUJI, loto.WithInclude(7, 8), loto.WithSeed(1))
+	if err != nil {
+		t.Fatalf("NewJumbo() error = %v", err)
+	}
+	tickets, err := jumbo.PickN(100)
+	if err != nil || len(tickets) != 100 {
+		t.Fatalf("PickN() = %d tickets, %v, want 100", len(tickets), err)
+	}
+	seen := make(map[loto.JumboTicket]bool)
+	for _, ticket := range tickets {
+		if ticket.Group != 7 && ticket.Group != 8 {
+			t.Errorf("PickN() ticket %s, want group 7 or 8", ticket)
+		}
+		if !config.Serials.Contains(ticket.Number) {
+			t.Errorf("PickN() ticket %s, want a serial number within %s", ticket, config.Serials)
+		}
+		if seen[ticket] {
+			t.Errorf("PickN() ticket %s is picked twice", ticket)
+		}
+		seen[ticket] = true
+	}
+
+	jumbo, _ = loto.NewJumbo(loto.JUMBO_TAKARAKUJI, loto.WithInclude(7, 8), loto.WithSeed(1))
+	if first, _ := jumbo.PickN(10); !slices.Equal(first, tickets[:10]) {
+		t.Errorf("PickN(10) = %v, want the first tickets of PickN(100) %v", first, tickets[:10])
+	}
+}
+
+// TestJumboGame_PickPacks tests that renban packs are consecutive and bara packs have every last digit
+func TestJumboGame_PickPacks(t *testing.T) {
+	config, _ := loto.Lookup(loto.JUMBO_TAKARAKUJI)
+
+	tests := []struct {
+		name string
+		pack loto.PackType
+		opts []loto.Option
+	}{
+		{"renban", loto.RENBAN, nil},
+		{"bara", loto.BARA, nil},
+		{"renban of an included group", loto.RENBAN, []loto.Option{loto.WithInclude(7)}},
+		{"bara of an included group", loto.BARA, []loto.Option{loto.WithInclude(7)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jumbo, err := loto.NewJumbo(loto.JUMBO_TAKARAKUJI, append(tt.opts, loto.WithSeed(1))...)
+			if err != nil {
+				t.Fatalf("NewJumbo() error = %v", err)
+			}
+			packs, err := jumbo.PickPacks(tt.pack, 5)
+			if err != nil {
+				t.Fatalf("PickPacks() error = %v", err)
+			}
+			if len(packs) != 5 {
+				t.Fatalf("PickPacks() returned %d packs, want 5", len(packs))
+			}
+
+			seen := make(map[loto.JumboTicket]bool)
+			for _, pack := range packs {
+				if pack.Type != tt.pack || len(pack.Tickets) != loto.PackSize {
+					t.Fatalf("PickPacks() pack = %+v, want %d %s tickets", pack, loto.PackSize, tt.pack)
+				}
+				for i, ticket := range pack.Tickets {
+					if !config.Groups.Contains(ticket.Group) || !config.Serials.Contains(ticket.Number) {
+						t.Errorf("PickPacks() ticket %s is out of the groups %s and the serial numbers %s", ticket, config.Groups, config.Serials)
+					}
+					if seen[ticket] {
+						t.Errorf("PickPacks() ticket %s is in two packs", ticket)
+					}
+					seen[ticket] = true
+					if ticket.Number%10 != i {
+						t.Errorf("PickPacks() ticket %d of the pack is %s, want the last digit %d", i, ticket, i)
+					}
+					if tt.opts != nil && ticket.Group != 7 {
+						t.Errorf("PickPacks() ticket %s, want group 7", ticket)
+					}
+
+					first := pack.Tickets[0]
+					sameTen := ticket.Group == first.Group && ticket.Number/10 == first.Number/10
+					if tt.pack == loto.RENBAN && !sameTen {
+						t.Errorf("PickPacks() renban ticket %s does not follow %s", ticket, first)
+					}
+					if tt.pack == loto.BARA && i > 0 && sameTen {
+						t.Errorf("PickPacks() bara tickets %s and %s are consecutive", first, ticket)
+					}
+				}
+			}
+		})
+	}
+
+	jumbo, _ := loto.NewJumbo(loto.JUMBO_TAKARAKUJI)
+	if _, err := jumbo.PickPacks("single", 1); err == nil {
+		t.Error("PickPacks() with an invalid pack type error = nil, want error")
+	}
+}
+
+// TestLoadGames_Jumbo tests loading a user-defined jumbo game and picking every pack it has
+func TestLoadGames_Jumbo(t *testing.T) {
+	content := `
+games:
+  - name: jumbo40
+    category: jumbo
+    price: 300
+    groups: {min: 1, max: 2}
+    serials: {min: 1000, max: 1019}
+`
+	path := filepath.Join(t.TempDir(), "games.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loto.LoadGames(path); err != nil {
+		t.Fatalf("LoadGames() error = %v", err)
+	}
+
+	jumbo, err := loto.NewJumbo("jumbo40", loto.WithSeed(1))
+	if err != nil {
+		t.Fatalf("NewJumbo() error = %v", err)
+	}
+	packs, err := jumbo.PickPacks(loto.RENBAN, 4)
+	if err != nil || len(packs) != 4 {
+		t.Fatalf("PickPacks() = %d packs, %v, want 4", len(packs), err)
+	}
+	if _, err := jumbo.PickPacks(loto.RENBAN, 5); !errors.Is(err, loto.ErrExhausted) {
+		t.Errorf("PickPacks() of more packs than possible error = %v, want ErrExhausted", err)
+	}
+	if _, err := jumbo.PickN(41); !errors.Is(err, loto.ErrExhausted) {
+		t.Errorf("PickN() of more tickets than possible error = %v, want ErrExhausted", err)
+	}
+	jumbo, _ = loto.NewJumbo("jumbo40", loto.WithCap())
+	if tickets, err := jumbo.PickN(41); err != nil || len(tickets) != 40 {
+		t.Errorf("PickN() with WithCap = %d tickets, %v, want 40", len(tickets), err)
+	}
+}
@@ -1,9 +1,13 @@
 package loto_test
 
 import (
+	"errors"
+	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/kawana77b/loto/internal/loto"
+	"github.com/kawana77b/loto/internal/util"
 )
 
 // TestLotteryType_Validate tests the Validate method of LotteryType
@@ -257,6 +261,17 @@ func TestBox_Operations(t *testing.T) {
 		}
 	})
 
+	t.Run("Remove", func(t *testing.T) {
+		box := loto.NewBox(1, 10)
+		box.Remove(4, 9, 99)
+		if box.Length() != 8 {
+			t.Errorf("Box.Remove() length = %v, want 8", box.Length())
+		}
+		if box.Contains(4) || box.Contains(9) {
+			t.Error("Box.Remove() didn't remove the items")
+		}
+	})
+
 	t.Run("Clear", func(t *testing.T) {
 		box := loto.NewBox(1, 10)
 		box.Clear()
@@ -294,30 +309,33 @@ func TestNewLottery(t *testing.T) {
 	tests := []struct {
 		name        string
 		lotteryType loto.LotteryType
-		wantNil     bool
+		wantErr     bool
 	}{
 		{
 			name:        "create loto6",
 			lotteryType: loto.LOTO_6,
-			wantNil:     false,
+			wantErr:     false,
 		},
 		{
 			name:        "create numbers3",
 			lotteryType: loto.NUMBERS_3,
-			wantNil:     false,
+			wantErr:     false,
 		},
 		{
 			name:        "invalid type",
 			lotteryType: loto.LotteryType("invalid"),
-			wantNil:     true,
+			wantErr:     true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			lottery := loto.NewLottery(tt.lotteryType)
-			if (lottery == nil) != tt.wantNil {
-				t.Errorf("NewLottery() = %v, wantNil %v", lottery, tt.wantNil)
+			lottery, err := loto.NewLottery(tt.lotteryType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLottery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (lottery == nil) != tt.wantErr {
+				t.Errorf("NewLottery() = %v, want nil only on error", lottery)
 			}
 		})
 	}
@@ -381,12 +399,15 @@ func TestLotteryGame_Pick(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			lottery := loto.NewLottery(tt.lotteryType)
-			if lottery == nil {
-				t.Fatal("NewLottery() returned nil")
+			lottery, err := loto.NewLottery(tt.lotteryType)
+			if err != nil {
+				t.Fatalf("NewLottery() error = %v", err)
 			}
 
-			result := lottery.Pick()
+			result, err := lottery.Pick()
+			if err != nil {
+				t.Fatalf("Pick() error = %v", err)
+			}
 
 			// Check count
 			if len(result) != tt.wantCount {
@@ -452,12 +473,15 @@ func TestLotteryGame_PickN(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			lottery := loto.NewLottery(tt.lotteryType)
-			if lottery == nil {
-				t.Fatal("NewLottery() returned nil")
+			lottery, err := loto.NewLottery(tt.lotteryType)
+			if err != nil {
+				t.Fatalf("NewLottery() error = %v", err)
 			}
 
-			results := lottery.PickN(tt.count)
+			results, err := lottery.PickN(tt.count)
+			if err != nil {
+				t.Fatalf("PickN() error = %v", err)
+			}
 
 			// Check count
 			if len(results) != tt.wantCount {
@@ -481,6 +505,204 @@ func TestLotteryGame_PickN(t *testing.T) {
 	}
 }
 
+// TestLotteryGame_ResultSpace tests the number of distinct results of each game and bet type
+func TestLotteryGame_ResultSpace(t *testing.T) {
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		bet         loto.BetType
+		want        string
+	}{
+		{name: "loto6", lotteryType: loto.LOTO_6, want: "6096454"},
+		{name: "loto7", lotteryType: loto.LOTO_7, want: "10295472"},
+		{name: "miniloto", lotteryType: loto.LOTO_MINI, want: "169911"},
+		{name: "numbers3 straight", lotteryType: loto.NUMBERS_3, bet: loto.STRAIGHT, want: "1000"},
+		{name: "numbers3 box", lotteryType: loto.NUMBERS_3, bet: loto.BOX, want: "210"},
+		{name: "numbers3 set", lotteryType: loto.NUMBERS_3, bet: loto.SET, want: "990"},
+		{name: "numbers3 mini", lotteryType: loto.NUMBERS_3, bet: loto.MINI, want: "100"},
+		{name: "numbers4 straight", lotteryType: loto.NUMBERS_4, bet: loto.STRAIGHT, want: "10000"},
+		{name: "numbers4 box", lotteryType: loto.NUMBERS_4, bet: loto.BOX, want: "705"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []loto.Option
+			if tt.bet != "" {
+				opts = append(opts, loto.WithBet(tt.bet))
+			}
+			lottery, err := loto.NewLottery(tt.lotteryType, opts...)
+			if err != nil {
+				t.Fatalf("NewLottery() error = %v", err)
+			}
+			if got := lottery.ResultSpace().String(); got != tt.want {
+				t.Errorf("ResultSpace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestLotteryGame_PickN_Exhausted tests that PickN never loops forever on a result space that is too small
+func TestLotteryGame_PickN_Exhausted(t *testing.T) {
+	t.Run("every result can be picked", func(t *testing.T) {
+		lottery, err := loto.NewLottery(loto.NUMBERS_3, loto.WithSeed(1), loto.WithBet(loto.BOX))
+		if err != nil {
+			t.Fatalf("NewLottery() error = %v", err)
+		}
+		results, err := lottery.PickN(210)
+		if err != nil {
+			t.Fatalf("PickN() error = %v", err)
+		}
+		if len(results) != 210 {
+			t.Errorf("PickN() length = %v, want 210", len(results))
+		}
+	})
+
+	t.Run("more results than possible", func(t *testing.T) {
+		lottery, err := loto.NewLottery(loto.NUMBERS_3, loto.WithSeed(1))
+		if err != nil {
+			t.Fatalf("NewLottery() error = %v", err)
+		}
+		if _, err := lottery.PickN(1001); !errors.Is(err, loto.ErrExhausted) {
+			t.Errorf("PickN() error = %v, want %v", err, loto.ErrExhausted)
+		}
+	})
+
+	t.Run("capped to every possible result", func(t *testing.T) {
+		lottery, err := loto.NewLottery(loto.NUMBERS_3, loto.WithSeed(1), loto.WithBet(loto.MINI), loto.WithCap())
+		if err != nil {
+			t.Fatalf("NewLottery() error = %v", err)
+		}
+		results, err := lottery.PickN(1001)
+		if err != nil {
+			t.Fatalf("PickN() error = %v", err)
+		}
+		if len(results) != 100 {
+			t.Errorf("PickN() length = %v, want 100", len(results))
+		}
+	})
+}
+
+// TestLotteryGame_IncludeExclude tests that every ticket contains the included numbers and none of the excluded ones
+func TestLotteryGame_IncludeExclude(t *testing.T) {
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		opts        []loto.Option
+		include     []int
+		exclude     []int
+		wantSpace   string
+	}{
+		{
+			name:        "loto6",
+			lotteryType: loto.LOTO_6,
+			include:     []int{7, 13},
+			exclude:     []int{4, 9},
+			wantSpace:   "82251", // C(39, 4)
+		},
+		{
+			name:        "numbers3 straight",
+			lotteryType: loto.NUMBERS_3,
+			include:     []int{7, 7},
+			wantSpace:   "28",
+		},
+		{
+			name:        "numbers4 box",
+			lotteryType: loto.NUMBERS_4,
+			opts:        []loto.Option{loto.WithBet(loto.BOX)},
+			include:     []int{1},
+			exclude:     []int{0, 2, 3, 4, 5, 6, 7},
+			wantSpace:   "9", // multisets of {1, 8, 9} with a 1, except 1111
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]loto.Option{
+				loto.WithSeed(1),
+				loto.WithInclude(tt.include...),
+				loto.WithExclude(tt.exclude...),
+			}, tt.opts...)
+			lottery, err := loto.NewLottery(tt.lotteryType, opts...)
+			if err != nil {
+				t.Fatalf("NewLottery() error = %v", err)
+			}
+			if got := lottery.ResultSpace().String(); got != tt.wantSpace {
+				t.Errorf("ResultSpace() = %v, want %v", got, tt.wantSpace)
+			}
+
+			for range 100 {
+				result, err := lottery.Pick()
+				if err != nil {
+					t.Fatalf("Pick() error = %v", err)
+				}
+				for _, n := range tt.include {
+					if !slices.Contains(result, n) {
+						t.Fatalf("Pick() = %v, want it to contain %d", result, n)
+					}
+				}
+				for _, n := range tt.exclude {
+					if slices.Contains(result, n) {
+						t.Fatalf("Pick() = %v, want it not to contain %d", result, n)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestLotteryGame_IncludeExclude_Invalid tests that impossible constraints are rejected
+func TestLotteryGame_IncludeExclude_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		opts        []loto.Option
+	}{
+		{
+			name:        "out of range",
+			lotteryType: loto.LOTO_6,
+			opts:        []loto.Option{loto.WithInclude(44)},
+		},
+		{
+			name:        "included and excluded",
+			lotteryType: loto.LOTO_6,
+			opts:        []loto.Option{loto.WithInclude(4), loto.WithExclude(4)},
+		},
+		{
+			name:        "too many included",
+			lotteryType: loto.LOTO_MINI,
+			opts:        []loto.Option{loto.WithInclude(1, 2, 3, 4, 5, 6)},
+		},
+		{
+			name:        "duplicate included",
+			lotteryType: loto.LOTO_6,
+			opts:        []loto.Option{loto.WithInclude(7, 7)},
+		},
+		{
+			name:        "too many excluded",
+			lotteryType: loto.LOTO_MINI,
+			opts:        []loto.Option{loto.WithExclude(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27)},
+		},
+		{
+			name:        "too many included digits",
+			lotteryType: loto.NUMBERS_3,
+			opts:        []loto.Option{loto.WithBet(loto.MINI), loto.WithInclude(1, 2, 3)},
+		},
+		{
+			name:        "box of a repdigit",
+			lotteryType: loto.NUMBERS_3,
+			opts:        []loto.Option{loto.WithBet(loto.BOX), loto.WithInclude(7, 7, 7)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := loto.NewLottery(tt.lotteryType, tt.opts...); err == nil {
+				t.Error("NewLottery() error = nil, want error")
+			}
+		})
+	}
+}
+
 // TestLotteryConfigs tests that all lottery configs are properly configured
 func TestLotteryConfigs(t *testing.T) {
 	expectedConfigs := []loto.LotteryType{
@@ -489,21 +711,30 @@ func TestLotteryConfigs(t *testing.T) {
 		loto.LOTO_MINI,
 		loto.NUMBERS_3,
 		loto.NUMBERS_4,
+		loto.BINGO_5,
+		loto.JUMBO_TAKARAKUJI,
 	}
 
 	for _, lotteryType := range expectedConfigs {
 		t.Run(string(lotteryType), func(t *testing.T) {
-			config, ok := loto.LotteryConfigs[lotteryType]
+			config, ok := loto.Lookup(lotteryType)
 			if !ok {
-				t.Fatalf("LotteryConfigs missing entry for %s", lotteryType)
+				t.Fatalf("Lookup() missing entry for %s", lotteryType)
 			}
 
 			// Check that config has valid values
-			if config.Count <= 0 {
-				t.Errorf("Config.Count = %v, want > 0", config.Count)
-			}
-			if config.Max <= config.Min {
-				t.Errorf("Config.Max = %v, want > Min (%v)", config.Max, config.Min)
+			if config.Category == loto.JUMBO {
+				// Jumbo tickets are a group and a serial number instead of numbers
+				if config.Groups.Max < config.Groups.Min || config.Serials.Max <= config.Serials.Min {
+					t.Errorf("Config.Groups = %v, Config.Serials = %v, want ranges", config.Groups, config.Serials)
+				}
+			} else {
+				if config.Count <= 0 {
+					t.Errorf("Config.Count = %v, want > 0", config.Count)
+				}
+				if config.Max <= config.Min {
+					t.Errorf("Config.Max = %v, want > Min (%v)", config.Max, config.Min)
+				}
 			}
 			if config.Category == "" {
 				t.Error("Config.Category is empty")
@@ -517,3 +748,208 @@ func TestLotteryConfigs(t *testing.T) {
 		})
 	}
 }
+
+// TestLotteryGame_Seed tests that the same seed always gives the same results
+func TestLotteryGame_Seed(t *testing.T) {
+	lotteryTypes := []loto.LotteryType{
+		loto.LOTO_6,
+		loto.NUMBERS_4,
+	}
+
+	for _, lotteryType := range lotteryTypes {
+		t.Run(string(lotteryType), func(t *testing.T) {
+			a, errA := loto.NewLottery(lotteryType, loto.WithSeed(42))
+			b, errB := loto.NewLottery(lotteryType, loto.WithSeed(42))
+			if errA != nil || errB != nil {
+				t.Fatalf("NewLottery() error = %v, %v", errA, errB)
+			}
+
+			resultsA, errA := a.PickN(10)
+			resultsB, errB := b.PickN(10)
+			if errA != nil || errB != nil {
+				t.Fatalf("PickN() error = %v, %v", errA, errB)
+			}
+			if !reflect.DeepEqual(resultsA, resultsB) {
+				t.Errorf("PickN() with same seed = %v, want %v", resultsB, resultsA)
+			}
+		})
+	}
+}
+
+// TestNewBox_Source tests that a box with a seeded source is reproducible
+func TestNewBox_Source(t *testing.T) {
+	a := loto.NewBox(1, 43, loto.WithBoxSource(util.NewSeededSource(7)))
+	b := loto.NewBox(1, 43, loto.WithBoxSource(util.NewSeededSource(7)))
+
+	if got, want := b.PickN(6), a.PickN(6); !reflect.DeepEqual(got, want) {
+		t.Errorf("Box.PickN() with same seed = %v, want %v", got, want)
+	}
+	if got, want := b.PickDupN(6), a.PickDupN(6); !reflect.DeepEqual(got, want) {
+		t.Errorf("Box.PickDupN() with same seed = %v, want %v", got, want)
+	}
+}
+
+// TestLotteryGame_Secure tests picks drawn from the cryptographically secure source
+func TestLotteryGame_Secure(t *testing.T) {
+	lottery, err := loto.NewLottery(loto.LOTO_6, loto.WithSecure())
+	if err != nil {
+		t.Fatalf("NewLottery() error = %v", err)
+	}
+
+	results, err := lottery.PickN(20)
+	if err != nil {
+		t.Fatalf("PickN() error = %v", err)
+	}
+	for _, result := range results {
+		if len(result) != 6 {
+			t.Fatalf("Pick() length = %v, want 6", len(result))
+		}
+		for i, num := range result {
+			if num < 1 || num > 43 {
+				t.Errorf("Pick() returned out of range number: %d", num)
+			}
+			if i > 0 && num <= result[i-1] {
+				t.Errorf("Pick() result not sorted or has duplicates: %v", result)
+			}
+		}
+	}
+}
+
+// TestBox_Uniform tests that every item of a box is drawn about equally often
+func TestBox_Uniform(t *testing.T) {
+	const draws = 60000
+	sources := map[string]loto.BoxOption{
+		"seeded": loto.WithBoxSource(util.NewSeededSource(1)),
+		"secure": loto.WithBoxSource(util.CryptoSource{}),
+	}
+
+	for name, opt := range sources {
+		t.Run(name, func(t *testing.T) {
+			box := loto.NewBox(0, 5, opt)
+			counts := make(map[int]int)
+			for _, num := range box.PickDupN(draws) {
+				counts[num]++
+			}
+			for num := 0; num <= 5; num++ {
+				// expected 10000 per item; allow a generous margin
+				if counts[num] < 9000 || counts[num] > 11000 {
+					t.Errorf("Box.PickDupN() drew %d %d times, want about %d", num, counts[num], draws/6)
+				}
+			}
+		})
+	}
+}
+
+// TestLotteryGame_Draw tests the Draw method of LotteryGame
+func TestLotteryGame_Draw(t *testing.T) {
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		wantCount   int
+		wantBonus   int
+	}{
+		{
+			name:        "loto6 draws 1 bonus number",
+			lotteryType: loto.LOTO_6,
+			wantCount:   6,
+			wantBonus:   1,
+		},
+		{
+			name:        "loto7 draws 2 bonus numbers",
+			lotteryType: loto.LOTO_7,
+			wantCount:   7,
+			wantBonus:   2,
+		},
+		{
+			name:        "miniloto draws 1 bonus number",
+			lotteryType: loto.LOTO_MINI,
+			wantCount:   5,
+			wantBonus:   1,
+		},
+		{
+			name:        "numbers3 draws no bonus number",
+			lotteryType: loto.NUMBERS_3,
+			wantCount:   3,
+			wantBonus:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lottery, err := loto.NewLottery(tt.lotteryType, loto.WithSeed(1))
+			if err != nil {
+				t.Fatalf("NewLottery() error = %v", err)
+			}
+
+			for range 100 {
+				draw := lottery.Draw()
+				if len(draw.Numbers) != tt.wantCount {
+					t.Fatalf("Draw() numbers length = %v, want %v", len(draw.Numbers), tt.wantCount)
+				}
+				if len(draw.Bonus) != tt.wantBonus {
+					t.Fatalf("Draw() bonus length = %v, want %v", len(draw.Bonus), tt.wantBonus)
+				}
+
+				// Bonus numbers are drawn without replacement
+				for _, bonus := range draw.Bonus {
+					if slices.Contains(draw.Numbers, bonus) {
+						t.Fatalf("Draw() bonus %d is also a main number: %v", bonus, draw)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestFormatNumbers tests the FormatNumbers function
+func TestFormatNumbers(t *testing.T) {
+	tests := []struct {
+		name     string
+		category loto.LotteryCategory
+		numbers  []int
+		want     string
+	}{
+		{
+			name:     "loto is zero-padded and comma separated",
+			category: loto.LOTO,
+			numbers:  []int{1, 7, 38},
+			want:     "01, 07, 38",
+		},
+		{
+			name:     "numbers are joined as digits",
+			category: loto.NUMBERS,
+			numbers:  []int{0, 3, 3},
+			want:     "033",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := loto.FormatNumbers(tt.category, tt.numbers); got != tt.want {
+				t.Errorf("FormatNumbers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestYen tests the String method of Yen
+func TestYen(t *testing.T) {
+	tests := []struct {
+		yen  loto.Yen
+		want string
+	}{
+		{yen: 0, want: "¥0"},
+		{yen: 200, want: "¥200"},
+		{yen: 5600, want: "¥5,600"},
+		{yen: 1234567, want: "¥1,234,567"},
+		{yen: -3000, want: "-¥3,000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.yen.String(); got != tt.want {
+				t.Errorf("Yen.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package loto
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// PrizeOdds holds the exact probability that a line wins a prize in a draw.
+type PrizeOdds struct {
+	Prize       string     // Name of the prize (e.g., "1st", "straight")
+	Tier        *PrizeTier // Prize tier of loto and bingo types (nil for numbers types)
+	Bet         BetType    // Bet type of numbers types (empty for loto types)
+	BoxType     *BoxType   // Box type of the ticket for box and set bets (nil otherwise)
+	Probability *big.Rat   // Probability that a line wins the prize
+}
+
+// ComputeOdds returns the exact probability of every prize of the lottery, from the highest.
+// Loto types count the ways to match the main and bonus numbers of each tier (hypergeometric distribution),
+// so the first matching tier wins as in Evaluate. Positional games and bingo types go through
+// the independent matches of their positions instead. Numbers types count the winning orderings of the digits
+// for the bet type and, for box and set bets, for each box type of the ticket.
+// An empty bet type means every bet type that can be played on the numbers type.
+func ComputeOdds(config LotteryConfig, bet BetType) ([]PrizeOdds, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Category == NUMBERS {
+		if bet != "" {
+			if err := bet.Validate(config); err != nil {
+				return nil, err
+			}
+			return numbersOdds(config, bet), nil
+		}
+		var odds []PrizeOdds
+		for _, b := range BetTypes() {
+			if b.Validate(config) == nil {
+				odds = append(odds, numbersOdds(config, b)...)
+			}
+		}
+		return odds, nil
+	}
+
+	if bet != "" {
+		return nil, fmt.Errorf("bet types are only available for numbers games")
+	}
+	if len(config.Tiers) == 0 {
+		return nil, fmt.Errorf("prize tiers are not defined for the game")
+	}
+	if config.Positional() {
+		return positionalOdds(config), nil
+	}
+	return lotoOdds(config), nil
+}
+
+// JackpotOdds returns the exact probability that a line matches every main number (every digit in order for numbers types).
+// Jumbo types match a ticket, one of the serial numbers of one of the groups.
+func JackpotOdds(config LotteryConfig) *big.Rat {
+	if config.Category == JUMBO {
+		tickets := int64(config.Groups.Max-config.Groups.Min+1) * int64(config.Serials.Max-config.Serials.Min+1)
+		return new(big.Rat).SetFrac64(1, tickets)
+	}
+	if config.Positional() {
+		results := big.NewInt(1)
+		for _, r := range config.Positions {
+			results.Mul(results, big.NewInt(int64(r.Max-r.Min+1)))
+		}
+		return new(big.Rat).SetFrac(big.NewInt(1), results)
+	}
+	size := int64(config.Max - config.Min + 1)
+	if config.AllowDuplicate {
+		return new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(big.NewInt(size), big.NewInt(int64(config.Count)), nil))
+	}
+	return new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Binomial(size, int64(config.Count)))
+}
+
+// AnyPrizeOdds returns the exact probability that a line wins any of the prizes.
+// The prizes must exclude each other, as for a single bet type.
+func AnyPrizeOdds(odds []PrizeOdds) *big.Rat {
+	total := new(big.Rat)
+	for _, o := range odds {
+		total.Add(total, o.Probability)
+	}
+	return total
+}
+
+// lotoOdds returns the probability of every tier of the loto type.
+func lotoOdds(config LotteryConfig) []PrizeOdds {
+	size := int64(config.Max - config.Min + 1)
+	count, bonus := int64(config.Count), int64(config.Bonus)
+	others := size - count - bonus
+
+	wins := make([]*big.Int, len(config.Tiers))
+	for i := range wins {
+		wins[i] = new(big.Int)
+	}
+	// Ways to pick the ticket with main matches, b bonus matches and the rest from the other numbers
+	for main := int64(0); main <= count; main++ {
+		for b := int64(0); b <= min(bonus, count-main); b++ {
+			rest := count - main - b
+			if rest > others {
+				continue
+			}
+			i := slices.IndexFunc(config.Tiers, func(tier PrizeTier) bool {
+				return int64(tier.Main) == main && int64(tier.Bonus) <= b
+			})
+			if i < 0 {
+				continue
+			}
+			ways := new(big.Int).Binomial(count, main)
+			ways.Mul(ways, new(big.Int).Binomial(bonus, b))
+			ways.Mul(ways, new(big.Int).Binomial(others, rest))
+			wins[i].Add(wins[i], ways)
+		}
+	}
+
+	total := new(big.Int).Binomial(size, count)
+	odds := make([]PrizeOdds, len(config.Tiers))
+	for i := range config.Tiers {
+		odds[i] = PrizeOdds{
+			Prize:       config.Tiers[i].Name(),
+			Tier:        &config.Tiers[i],
+			Probability: new(big.Rat).SetFrac(wins[i], total),
+		}
+	}
+	return odds
+}
+
+// positionalOdds returns the probability of every tier of the positional game.
+// Each position matches with a probability of one in the size of its range, independently of the others,
+// so every set of matching positions is weighed by the product of the probabilities of its positions.
+// Bingo tiers are won by the complete lines of the matching positions, and the other tiers by their count.
+func positionalOdds(config LotteryConfig) []PrizeOdds {
+	wins := make([]*big.Rat, len(config.Tiers))
+	for i := range wins {
+		wins[i] = new(big.Rat)
+	}
+	isBingo := config.Category == BINGO
+	positions := len(config.Positions)
+
+	if isBingo {
+		// Every set of matching positions of the card
+		for matched := uint(0); matched < 1<<positions; matched++ {
+			i := slices.IndexFunc(config.Tiers, func(tier PrizeTier) bool {
+				return tier.Lines == countLines(matched)
+			})
+			if i < 0 {
+				continue
+			}
+			probability := big.NewRat(1, 1)
+			for pos, r := range config.Positions {
+				size := int64(r.Max - r.Min + 1)
+				if matched&(1<<pos) != 0 {
+					probability.Mul(probability, big.NewRat(1, size))
+				} else {
+					probability.Mul(probability, big.NewRat(size-1, size))
+				}
+			}
+			wins[i].Add(wins[i], probability)
+		}
+	} else {
+		// Probability of each count of matching positions, adding one position at a time
+		counts := []*big.Rat{big.NewRat(1, 1)}
+		for _, r := range config.Positions {
+			size := int64(r.Max - r.Min + 1)
+			next := make([]*big.Rat, len(counts)+1)
+			for k := range next {
+				next[k] = new(big.Rat)
+			}
+			for k, p := range counts {
+				next[k].Add(next[k], new(big.Rat).Mul(p, big.NewRat(size-1, size)))
+				next[k+1].Add(next[k+1], new(big.Rat).Mul(p, big.NewRat(1, size)))
+			}
+			counts = next
+		}
+		for main, p := range counts {
+			if i := slices.IndexFunc(config.Tiers, func(tier PrizeTier) bool { return tier.Main == main }); i >= 0 {
+				wins[i].Add(wins[i], p)
+			}
+		}
+	}
+
+	odds := make([]PrizeOdds, len(config.Tiers))
+	for i := range config.Tiers {
+		odds[i] = PrizeOdds{
+			Prize:       config.Tiers[i].Name(),
+			Tier:        &config.Tiers[i],
+			Probability: wins[i],
+		}
+	}
+	return odds
+}
+
+// numbersOdds returns the probability of every prize of the bet type of the numbers type.
+func numbersOdds(config LotteryConfig, bet BetType) []PrizeOdds {
+	size := int64(config.Max - config.Min + 1)
+	draws := new(big.Int).Exp(big.NewInt(size), big.NewInt(int64(config.Count)), nil)
+	probability := func(wins int64) *big.Rat {
+		return new(big.Rat).SetFrac(big.NewInt(wins), draws)
+	}
+
+	switch bet {
+	case STRAIGHT:
+		return []PrizeOdds{{Prize: STRAIGHT_PRIZE, Bet: bet, Probability: probability(1)}}
+	case MINI:
+		// Only the last two digits count, so the other digits may be anything
+		wins := new(big.Int).Exp(big.NewInt(size), big.NewInt(int64(config.Count-MINI.Digits(config))), nil)
+		return []PrizeOdds{{Prize: MINI_PRIZE, Bet: bet, Probability: new(big.Rat).SetFrac(wins, draws)}}
+	}
+
+	var odds []PrizeOdds
+	for _, boxType := range boxTypes(config) {
+		if !boxType.Boxable() {
+			continue
+		}
+		permutations := int64(boxType.Permutations)
+		if bet == BOX {
+			odds = append(odds, PrizeOdds{Prize: BOX_PRIZE, Bet: bet, BoxType: &boxType, Probability: probability(permutations)})
+			continue
+		}
+		odds = append(odds,
+			PrizeOdds{Prize: SET_STRAIGHT_PRIZE, Bet: bet, BoxType: &boxType, Probability: probability(1)},
+			PrizeOdds{Prize: SET_BOX_PRIZE, Bet: bet, BoxType: &boxType, Probability: probability(permutations - 1)},
+		)
+	}
+	return odds
+}
+
+// boxTypes returns every box type of the tickets of the numbers type, from the most permutations.
+// Each box type is a partition of the digits into groups of the same digit.
+func boxTypes(config LotteryConfig) []BoxType {
+	size := config.Max - config.Min + 1
+	var types []BoxType
+	var partition func(digits []int, left, largest int)
+	partition = func(digits []int, left, largest int) {
+		if left == 0 {
+			types = append(types, ClassifyBox(digits))
+			return
+		}
+		next := config.Min + len(slices.Compact(slices.Clone(digits)))
+		if next-config.Min >= size {
+			return
+		}
+		for n := min(left, largest); n >= 1; n-- {
+			partition(append(slices.Clone(digits), slices.Repeat([]int{next}, n)...), left-n, n)
+		}
+	}
+	partition(nil, config.Count, config.Count)
+
+	slices.SortStableFunc(types, func(a, b BoxType) int {
+		return b.Permutations - a.Permutations
+	})
+	return types
+}
@@ -0,0 +1,158 @@
+package loto_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestComputeOdds tests the exact odds of every prize of the built-in games
+func TestComputeOdds(t *testing.T) {
+	tests := []struct {
+		lotteryType loto.LotteryType
+		bet         loto.BetType
+		want        []string
+	}{
+		{loto.LOTO_6, "", []string{"1/6096454", "3/3048227", "108/3048227", "4995/3048227", "11100/435461"}},
+		{loto.LOTO_7, "", []string{"1/10295472", "7/5147736", "49/2573868", "3045/3431824", "35525/2573868", "3675/155992"}},
+		{loto.LOTO_MINI, "", []string{"1/169911", "5/169911", "125/169911", "3250/169911"}},
+		{loto.NUMBERS_3, loto.STRAIGHT, []string{"1/1000"}},
+		{loto.NUMBERS_3, loto.BOX, []string{"3/500", "3/1000"}},
+		{loto.NUMBERS_3, loto.SET, []string{"1/1000", "1/200", "1/1000", "1/500"}},
+		{loto.NUMBERS_3, loto.MINI, []string{"1/100"}},
+		{loto.NUMBERS_4, loto.BOX, []string{"3/1250", "3/2500", "3/5000", "1/2500"}},
+		{loto.BINGO_5, "", []string{"1/390625", "16/390625", "48/390625", "192/390625", "1248/390625", "6656/390625", "56832/390625"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.lotteryType)+" "+string(tt.bet), func(t *testing.T) {
+			config, _ := loto.Lookup(tt.lotteryType)
+			odds, err := loto.ComputeOdds(config, tt.bet)
+			if err != nil {
+				t.Fatalf("ComputeOdds() error = %v", err)
+			}
+			if len(odds) != len(tt.want) {
+				t.Fatalf("ComputeOdds() = %d prizes, want %d", len(odds), len(tt.want))
+			}
+			for i, o := range odds {
+				if got := o.Probability.RatString(); got != tt.want[i] {
+					t.Errorf("ComputeOdds() %s = %s, want %s", o.Prize, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestComputeOdds_Custom tests that the odds of a custom game add up with the tickets that win nothing
+func TestComputeOdds_Custom(t *testing.T) {
+	config := loto.LotteryConfig{
+		Category: loto.LOTO,
+		Count:    4,
+		Min:      1,
+		Max:      10,
+		Bonus:    2,
+		Tiers: []loto.PrizeTier{
+			{Rank: 1, Main: 4},
+			{Rank: 2, Main: 3, Bonus: 1},
+			{Rank: 3, Main: 3},
+			{Rank: 4, Main: 2, Bonus: 2},
+		},
+	}
+	odds, err := loto.ComputeOdds(config, "")
+	if err != nil {
+		t.Fatalf("ComputeOdds() error = %v", err)
+	}
+
+	// Count the winning tickets of a draw by brute force
+	draw := loto.Draw{Numbers: []int{1, 2, 3, 4}, Bonus: []int{5, 6}}
+	wins := make([]int64, len(config.Tiers))
+	for _, ticket := range combinations([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 4) {
+		if result := loto.Evaluate(config, ticket, draw); result.Won() {
+			wins[result.Tier.Rank-1]++
+		}
+	}
+	for i, o := range odds {
+		if want := big.NewRat(wins[i], 210); o.Probability.Cmp(want) != 0 {
+			t.Errorf("ComputeOdds() %s = %s, want %s", o.Prize, o.Probability.RatString(), want.RatString())
+		}
+	}
+}
+
+// TestComputeOdds_Positional tests the odds of a positional game against every possible ticket
+func TestComputeOdds_Positional(t *testing.T) {
+	config := loto.LotteryConfig{
+		Category:  loto.LOTO,
+		Count:     3,
+		Min:       1,
+		Max:       9,
+		Positions: []loto.Range{{Min: 1, Max: 2}, {Min: 3, Max: 5}, {Min: 6, Max: 9}},
+		Tiers: []loto.PrizeTier{
+			{Rank: 1, Main: 3},
+			{Rank: 2, Main: 2},
+			{Rank: 3, Main: 1},
+		},
+	}
+	odds, err := loto.ComputeOdds(config, "")
+	if err != nil {
+		t.Fatalf("ComputeOdds() error = %v", err)
+	}
+
+	// Count the winning tickets of a draw by brute force
+	draw := loto.Draw{Numbers: []int{1, 3, 6}}
+	wins := make([]int64, len(config.Tiers))
+	for a := 1; a <= 2; a++ {
+		for b := 3; b <= 5; b++ {
+			for c := 6; c <= 9; c++ {
+				if result := loto.Evaluate(config, []int{a, b, c}, draw); result.Won() {
+					wins[result.Tier.Rank-1]++
+				}
+			}
+		}
+	}
+	for i, o := range odds {
+		if want := big.NewRat(wins[i], 24); o.Probability.Cmp(want) != 0 {
+			t.Errorf("ComputeOdds() %s = %s, want %s", o.Prize, o.Probability.RatString(), want.RatString())
+		}
+	}
+	if got := loto.JackpotOdds(config).RatString(); got != "1/24" {
+		t.Errorf("JackpotOdds() = %s, want 1/24", got)
+	}
+}
+
+// TestComputeOdds_Invalid tests that ComputeOdds rejects games without prizes and invalid bets
+func TestComputeOdds_Invalid(t *testing.T) {
+	loto6, _ := loto.Lookup(loto.LOTO_6)
+	numbers4, _ := loto.Lookup(loto.NUMBERS_4)
+	noTiers := loto6
+	noTiers.Tiers = nil
+
+	if _, err := loto.ComputeOdds(noTiers, ""); err == nil {
+		t.Error("ComputeOdds() without tiers error = nil, want error")
+	}
+	if _, err := loto.ComputeOdds(loto6, loto.BOX); err == nil {
+		t.Error("ComputeOdds() with a bet on loto6 error = nil, want error")
+	}
+	if _, err := loto.ComputeOdds(numbers4, loto.MINI); err == nil {
+		t.Error("ComputeOdds() with mini on numbers4 error = nil, want error")
+	}
+}
+
+// TestJackpotOdds tests the odds of matching every main number
+func TestJackpotOdds(t *testing.T) {
+	tests := map[loto.LotteryType]string{
+		loto.LOTO_6:           "1/6096454",
+		loto.LOTO_7:           "1/10295472",
+		loto.LOTO_MINI:        "1/169911",
+		loto.NUMBERS_3:        "1/1000",
+		loto.NUMBERS_4:        "1/10000",
+		loto.BINGO_5:          "1/390625",
+		loto.JUMBO_TAKARAKUJI: "1/20000000",
+	}
+	for lotteryType, want := range tests {
+		config, _ := loto.Lookup(lotteryType)
+		if got := loto.JackpotOdds(config).RatString(); got != want {
+			t.Errorf("JackpotOdds(%s) = %s, want %s", lotteryType, got, want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package loto
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Name returns the display name of the prize tier (e.g., "1st", "2nd").
+func (p PrizeTier) Name() string {
+	suffix := "th"
+	switch p.Rank % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+	if p.Rank%100 >= 11 && p.Rank%100 <= 13 {
+		suffix = "th"
+	}
+	return fmt.Sprintf("%d%s", p.Rank, suffix)
+}
+
+// CheckResult holds the result of checking a ticket against a draw.
+type CheckResult struct {
+	Main  int        // Number of matching main numbers
+	Bonus int        // Number of matching bonus numbers
+	Lines int        // Number of complete lines of the card (bingo types only)
+	Tier  *PrizeTier // Prize tier won (nil if the ticket wins nothing)
+}
+
+// Won reports whether the ticket won a prize.
+func (r CheckResult) Won() bool {
+	return r.Tier != nil
+}
+
+// Check validates the ticket and the draw against the given lottery type
+// and returns the prize tier the ticket wins.
+func Check(t LotteryType, ticket []int, draw Draw) (CheckResult, error) {
+	config, ok := Lookup(t)
+	if !ok {
+		return CheckResult{}, fmt.Errorf("invalid lottery type: %s", t)
+	}
+	if len(config.Tiers) == 0 {
+		return CheckResult{}, fmt.Errorf("prize tiers are not defined for %s", t)
+	}
+	if err := config.ValidateNumbers(ticket); err != nil {
+		return CheckResult{}, fmt.Errorf("invalid ticket: %w", err)
+	}
+	if err := config.ValidateDraw(draw); err != nil {
+		return CheckResult{}, fmt.Errorf("invalid draw: %w", err)
+	}
+	return Evaluate(config, ticket, draw), nil
+}
+
+// Evaluate counts the matching numbers of the ticket and returns the prize tier it wins.
+// The tiers of the config are evaluated in order and the first matching tier wins.
+// Bingo types also count the complete lines of the card, which decide the tier.
+// The ticket and the draw are assumed to be valid for the config.
+func Evaluate(config LotteryConfig, ticket []int, draw Draw) CheckResult {
+	var result CheckResult
+	for _, num := range ticket {
+		if slices.Contains(draw.Numbers, num) {
+			result.Main++
+		} else if slices.Contains(draw.Bonus, num) {
+			result.Bonus++
+		}
+	}
+	isBingo := config.Category == BINGO
+	if isBingo {
+		result.Lines = countLines(matchPositions(ticket, draw.Numbers))
+	}
+
+	for i, tier := range config.Tiers {
+		if (isBingo && result.Lines == tier.Lines) || (!isBingo && result.Main == tier.Main && result.Bonus >= tier.Bonus) {
+			result.Tier = &config.Tiers[i]
+			break
+		}
+	}
+	return result
+}
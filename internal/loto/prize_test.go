@@ -0,0 +1,95 @@
+package loto_test
+
+import (
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestCheck tests the Check function for every loto prize tier
+func TestCheck(t *testing.T) {
+	loto6Draw := loto.Draw{Numbers: []int{1, 2, 3, 4, 5, 6}, Bonus: []int{7}}
+	loto7Draw := loto.Draw{Numbers: []int{1, 2, 3, 4, 5, 6, 7}, Bonus: []int{8, 9}}
+	miniDraw := loto.Draw{Numbers: []int{1, 2, 3, 4, 5}, Bonus: []int{6}}
+
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		ticket      []int
+		draw        loto.Draw
+		wantRank    int // 0 means no prize
+	}{
+		{"loto6 1st", loto.LOTO_6, []int{1, 2, 3, 4, 5, 6}, loto6Draw, 1},
+		{"loto6 2nd", loto.LOTO_6, []int{1, 2, 3, 4, 5, 7}, loto6Draw, 2},
+		{"loto6 3rd", loto.LOTO_6, []int{1, 2, 3, 4, 5, 43}, loto6Draw, 3},
+		{"loto6 4th", loto.LOTO_6, []int{1, 2, 3, 4, 42, 43}, loto6Draw, 4},
+		{"loto6 5th", loto.LOTO_6, []int{1, 2, 3, 7, 42, 43}, loto6Draw, 5},
+		{"loto6 no prize", loto.LOTO_6, []int{1, 2, 7, 41, 42, 43}, loto6Draw, 0},
+		{"loto7 1st", loto.LOTO_7, []int{1, 2, 3, 4, 5, 6, 7}, loto7Draw, 1},
+		{"loto7 2nd", loto.LOTO_7, []int{1, 2, 3, 4, 5, 6, 9}, loto7Draw, 2},
+		{"loto7 3rd", loto.LOTO_7, []int{1, 2, 3, 4, 5, 6, 37}, loto7Draw, 3},
+		{"loto7 4th", loto.LOTO_7, []int{1, 2, 3, 4, 5, 8, 9}, loto7Draw, 4},
+		{"loto7 5th", loto.LOTO_7, []int{1, 2, 3, 4, 35, 36, 37}, loto7Draw, 5},
+		{"loto7 6th", loto.LOTO_7, []int{1, 2, 3, 8, 35, 36, 37}, loto7Draw, 6},
+		{"loto7 3 main without bonus", loto.LOTO_7, []int{1, 2, 3, 34, 35, 36, 37}, loto7Draw, 0},
+		{"miniloto 1st", loto.LOTO_MINI, []int{1, 2, 3, 4, 5}, miniDraw, 1},
+		{"miniloto 2nd", loto.LOTO_MINI, []int{1, 2, 3, 4, 6}, miniDraw, 2},
+		{"miniloto 3rd", loto.LOTO_MINI, []int{1, 2, 3, 4, 31}, miniDraw, 3},
+		{"miniloto 4th", loto.LOTO_MINI, []int{1, 2, 3, 30, 31}, miniDraw, 4},
+		{"miniloto no prize", loto.LOTO_MINI, []int{1, 2, 6, 30, 31}, miniDraw, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := loto.Check(tt.lotteryType, tt.ticket, tt.draw)
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+
+			gotRank := 0
+			if result.Won() {
+				gotRank = result.Tier.Rank
+			}
+			if gotRank != tt.wantRank {
+				t.Errorf("Check() rank = %v, want %v (main %d, bonus %d)", gotRank, tt.wantRank, result.Main, result.Bonus)
+			}
+		})
+	}
+}
+
+// TestCheck_Invalid tests that Check rejects invalid tickets and draws
+func TestCheck_Invalid(t *testing.T) {
+	validDraw := loto.Draw{Numbers: []int{1, 2, 3, 4, 5, 6}, Bonus: []int{7}}
+
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		ticket      []int
+		draw        loto.Draw
+	}{
+		{"invalid type", loto.LotteryType("invalid"), []int{1, 2, 3, 4, 5, 6}, validDraw},
+		{"too few numbers", loto.LOTO_6, []int{1, 2, 3}, validDraw},
+		{"out of range", loto.LOTO_6, []int{1, 2, 3, 4, 5, 44}, validDraw},
+		{"duplicated number", loto.LOTO_6, []int{1, 1, 3, 4, 5, 6}, validDraw},
+		{"missing bonus", loto.LOTO_6, []int{1, 2, 3, 4, 5, 6}, loto.Draw{Numbers: []int{1, 2, 3, 4, 5, 6}}},
+		{"bonus is a main number", loto.LOTO_6, []int{1, 2, 3, 4, 5, 6}, loto.Draw{Numbers: []int{1, 2, 3, 4, 5, 6}, Bonus: []int{6}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := loto.Check(tt.lotteryType, tt.ticket, tt.draw); err == nil {
+				t.Error("Check() error = nil, want error")
+			}
+		})
+	}
+}
+
+// TestPrizeTier_Name tests the Name method of PrizeTier
+func TestPrizeTier_Name(t *testing.T) {
+	tests := map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 21: "21st"}
+	for rank, want := range tests {
+		if got := (loto.PrizeTier{Rank: rank}).Name(); got != want {
+			t.Errorf("PrizeTier.Name() = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package loto
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Registry holds the available lottery types and their configurations.
+// Every registered configuration has been validated.
+type Registry struct {
+	mu      sync.RWMutex
+	configs map[LotteryType]LotteryConfig
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		configs: make(map[LotteryType]LotteryConfig),
+	}
+}
+
+// Register validates the configuration and adds the lottery type to the registry.
+// A lottery type can only be registered once.
+func (r *Registry) Register(t LotteryType, config LotteryConfig) error {
+	if err := validateGameName(t.String()); err != nil {
+		return err
+	}
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("game %s: %w", t, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.configs[t]; ok {
+		return fmt.Errorf("game %s is already defined", t)
+	}
+	r.configs[t] = config
+	return nil
+}
+
+// Cancel adds dates without a draw (e.g., "2026-05-04") to the schedule of the lottery type.
+// The schedule is copied, so the configurations that share it are not changed.
+func (r *Registry) Cancel(t LotteryType, dates ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	config, ok := r.configs[t]
+	if !ok {
+		return fmt.Errorf("game %s is not defined", t)
+	}
+	if config.Schedule == nil {
+		return fmt.Errorf("game %s has no schedule", t)
+	}
+	schedule := *config.Schedule
+	schedule.Cancelled = slices.Concat(schedule.Cancelled, dates)
+	if err := schedule.Validate(); err != nil {
+		return fmt.Errorf("game %s: %w", t, err)
+	}
+	config.Schedule = &schedule
+	r.configs[t] = config
+	return nil
+}
+
+// Lookup returns the configuration of the lottery type.
+func (r *Registry) Lookup(t LotteryType) (LotteryConfig, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	config, ok := r.configs[t]
+	return config, ok
+}
+
+// All returns all registered lottery types sorted alphabetically.
+func (r *Registry) All() []LotteryType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	types := make([]LotteryType, 0, len(r.configs))
+	for t := range r.configs {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	return types
+}
+
+// DefaultRegistry is the registry of the built-in games and the games loaded from games files.
+var DefaultRegistry = newDefaultRegistry()
+
+// newDefaultRegistry creates a registry of the built-in games.
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	for t, config := range builtinConfigs {
+		if err := r.Register(t, config); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register validates the configuration and adds the lottery type to the default registry.
+func Register(t LotteryType, config LotteryConfig) error {
+	return DefaultRegistry.Register(t, config)
+}
+
+// Cancel adds dates without a draw to the schedule of the lottery type in the default registry.
+func Cancel(t LotteryType, dates ...string) error {
+	return DefaultRegistry.Cancel(t, dates...)
+}
+
+// Lookup returns the configuration of the lottery type from the default registry.
+func Lookup(t LotteryType) (LotteryConfig, bool) {
+	return DefaultRegistry.Lookup(t)
+}
+
+// validateGameName checks that a game name can be used as a command argument.
+func validateGameName(name string) error {
+	if name == "" {
+		return fmt.Errorf("game name is empty")
+	}
+	if strings.ContainsFunc(name, unicode.IsSpace) {
+		return fmt.Errorf("game name %q contains spaces", name)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package loto_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestRegistry_Register tests that the registry rejects invalid configurations
+func TestRegistry_Register(t *testing.T) {
+	valid := loto.LotteryConfig{Category: loto.LOTO, Count: 5, Min: 1, Max: 50}
+
+	tests := []struct {
+		name    string
+		config  func(c loto.LotteryConfig) loto.LotteryConfig
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			config: func(c loto.LotteryConfig) loto.LotteryConfig { return c },
+		},
+		{
+			name:    "min greater than max",
+			config:  func(c loto.LotteryConfig) loto.LotteryConfig { c.Min, c.Max = 50, 1; return c },
+			wantErr: true,
+		},
+		{
+			name:    "count larger than range without duplicates",
+			config:  func(c loto.LotteryConfig) loto.LotteryConfig { c.Max = 4; return c },
+			wantErr: true,
+		},
+		{
+			name: "count larger than range with duplicates",
+			config: func(c loto.LotteryConfig) loto.LotteryConfig {
+				c.Category, c.Max, c.AllowDuplicate = loto.NUMBERS, 4, true
+				return c
+			},
+		},
+		{
+			name:    "count and bonus larger than range",
+			config:  func(c loto.LotteryConfig) loto.LotteryConfig { c.Max, c.Bonus = 5, 1; return c },
+			wantErr: true,
+		},
+		{
+			name:    "zero count",
+			config:  func(c loto.LotteryConfig) loto.LotteryConfig { c.Count = 0; return c },
+			wantErr: true,
+		},
+		{
+			name:    "negative count",
+			config:  func(c loto.LotteryConfig) loto.LotteryConfig { c.Count = -1; return c },
+			wantErr: true,
+		},
+		{
+			name:    "missing category",
+			config:  func(c loto.LotteryConfig) loto.LotteryConfig { c.Category = ""; return c },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := loto.NewRegistry()
+			err := registry.Register(loto.LotteryType("game"), tt.config(valid))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Registry.Register() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, ok := registry.Lookup(loto.LotteryType("game")); ok == tt.wantErr {
+				t.Errorf("Registry.Lookup() ok = %v, want %v", ok, !tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestRegistry_All tests registering, looking up and listing games
+func TestRegistry_All(t *testing.T) {
+	registry := loto.NewRegistry()
+	config := loto.LotteryConfig{Category: loto.LOTO, Count: 5, Min: 1, Max: 50}
+
+	for _, name := range []loto.LotteryType{"zeta", "alpha", "mid"} {
+		if err := registry.Register(name, config); err != nil {
+			t.Fatalf("Registry.Register() error = %v", err)
+		}
+	}
+	if err := registry.Register("alpha", config); err == nil {
+		t.Error("Registry.Register() of a registered game error = nil, want error")
+	}
+
+	want := []loto.LotteryType{"alpha", "mid", "zeta"}
+	if got := registry.All(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Registry.All() = %v, want %v", got, want)
+	}
+	if _, ok := registry.Lookup("missing"); ok {
+		t.Error("Registry.Lookup() of a missing game ok = true, want false")
+	}
+}
+
+// TestDefaultRegistry tests that every built-in configuration is registered and valid
+func TestDefaultRegistry(t *testing.T) {
+	for _, lotteryType := range []loto.LotteryType{loto.LOTO_6, loto.LOTO_7, loto.LOTO_MINI, loto.NUMBERS_3, loto.NUMBERS_4, loto.BINGO_5, loto.JUMBO_TAKARAKUJI} {
+		config, ok := loto.Lookup(lotteryType)
+		if !ok {
+			t.Fatalf("Lookup() missing entry for %s", lotteryType)
+		}
+		if err := config.Validate(); err != nil {
+			t.Errorf("Lookup(%s).Validate() error = %v", lotteryType, err)
+		}
+	}
+}
+
+// TestRegistry_Cancel tests that cancelled dates are added to a copy of the schedule of a game
+func TestRegistry_Cancel(t *testing.T) {
+	config, _ := loto.Lookup(loto.LOTO_6)
+	registry := loto.NewRegistry()
+	if err := registry.Register(loto.LOTO_6, config); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	// Monday, May 4, 2026
+	cancelled := time.Date(2026, 5, 4, 0, 0, 0, 0, loto.JST)
+	if err := registry.Cancel(loto.LOTO_6, "2026-05-04"); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	got, _ := registry.Lookup(loto.LOTO_6)
+	if got.Schedule.HasDraw(cancelled) {
+		t.Error("HasDraw() on the cancelled date = true, want false")
+	}
+	if !config.Schedule.HasDraw(cancelled) {
+		t.Error("HasDraw() of the default registry on the cancelled date = false, want true")
+	}
+	// The cancelled draw is not counted, so the next draw takes its draw number
+	want := config.Schedule.Next(cancelled, 1)[0].Number
+	if draws := got.Schedule.Next(cancelled, 1); draws[0].Number != want || draws[0].Time.Format(time.DateOnly) != "2026-05-07" {
+		t.Errorf("Next() = %v, want draw %d on 2026-05-07", draws, want)
+	}
+
+	if err := registry.Cancel(loto.LOTO_6, "May 4"); err == nil {
+		t.Error("Cancel() with an invalid date error = nil, want error")
+	}
+	if err := registry.Cancel(loto.LOTO_7, "2026-05-08"); err == nil {
+		t.Error("Cancel() of an unregistered game error = nil, want error")
+	}
+}
@@ -0,0 +1,321 @@
+package loto
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v3"
+)
+
+// Format represents an output format.
+type Format string
+
+const (
+	// Output formats
+	TABLE    = Format("table")
+	JSON     = Format("json")
+	JSONL    = Format("jsonl")
+	CSV      = Format("csv")
+	TSV      = Format("tsv")
+	YAML     = Format("yaml")
+	MARKDOWN = Format("markdown")
+	PLAIN    = Format("plain")
+)
+
+// Formats returns all output formats.
+func Formats() []Format {
+	return []Format{TABLE, JSON, JSONL, CSV, TSV, YAML, MARKDOWN, PLAIN}
+}
+
+// Validate checks if the output format is valid.
+func (f Format) Validate() error {
+	if !slices.Contains(Formats(), f) {
+		names := make([]string, 0, len(Formats()))
+		for _, format := range Formats() {
+			names = append(names, string(format))
+		}
+		return fmt.Errorf("invalid output format: %s. It must be one of %s", f, strings.Join(names, ", "))
+	}
+	return nil
+}
+
+// Column describes a column of a Dataset.
+type Column struct {
+	Key   string // Key of the column in structured formats (json, jsonl, yaml)
+	Title string // Title of the column in tabular formats
+	Index bool   // Whether the column only numbers the rows (omitted in plain format)
+}
+
+// Dataset is tabular data that can be rendered in any output format.
+// Text formats render the cells with fmt.Sprint (nil as "-"),
+// structured formats encode the cells as-is.
+type Dataset struct {
+	Title   string   // Optional title, rendered above the data in human-readable formats
+	Columns []Column // Columns of the data
+	Rows    [][]any  // Rows of cells, one cell per column
+	Footer  string   // Optional summary, rendered below the data in human-readable formats
+}
+
+// Append adds a row of cells to the dataset.
+func (d *Dataset) Append(cells ...any) {
+	d.Rows = append(d.Rows, cells)
+}
+
+// Renderer writes a Dataset in an output format.
+type Renderer interface {
+	Render(w io.Writer, data *Dataset) error
+}
+
+// RendererFunc is an adapter to allow the use of ordinary functions as renderers.
+type RendererFunc func(w io.Writer, data *Dataset) error
+
+// Render calls f(w, data).
+func (f RendererFunc) Render(w io.Writer, data *Dataset) error {
+	return f(w, data)
+}
+
+// NewRenderer returns the renderer of the output format.
+func NewRenderer(f Format) (Renderer, error) {
+	switch f {
+	case TABLE:
+		return RendererFunc(renderTable), nil
+	case JSON:
+		return RendererFunc(renderJSON), nil
+	case JSONL:
+		return RendererFunc(renderJSONL), nil
+	case CSV:
+		return RendererFunc(func(w io.Writer, data *Dataset) error {
+			return renderDelimited(w, data, ',')
+		}), nil
+	case TSV:
+		return RendererFunc(func(w io.Writer, data *Dataset) error {
+			return renderDelimited(w, data, '\t')
+		}), nil
+	case YAML:
+		return RendererFunc(renderYAML), nil
+	case MARKDOWN:
+		return RendererFunc(renderMarkdown), nil
+	case PLAIN:
+		return RendererFunc(renderPlain), nil
+	}
+	return nil, f.Validate()
+}
+
+// Render writes the dataset to w in the output format.
+func Render(w io.Writer, f Format, data *Dataset) error {
+	renderer, err := NewRenderer(f)
+	if err != nil {
+		return err
+	}
+	return renderer.Render(w, data)
+}
+
+// titles returns the titles of the columns.
+func (d *Dataset) titles() []string {
+	titles := make([]string, len(d.Columns))
+	for i, column := range d.Columns {
+		titles[i] = column.Title
+	}
+	return titles
+}
+
+// formatCell formats a cell for text formats.
+func formatCell(cell any) string {
+	if cell == nil {
+		return "-"
+	}
+	return fmt.Sprint(cell)
+}
+
+// formatRow formats the cells of a row for text formats.
+func formatRow(row []any) []string {
+	cells := make([]string, len(row))
+	for i, cell := range row {
+		cells[i] = formatCell(cell)
+	}
+	return cells
+}
+
+// tableFormatter is implemented by cells that span several lines in tables, like bingo cards.
+// Other text formats keep one line per row, so they use the String method instead.
+type tableFormatter interface {
+	TableString() string
+}
+
+// formatTableRow formats the cells of a row for the table format.
+func formatTableRow(row []any) []string {
+	cells := formatRow(row)
+	for i, cell := range row {
+		if f, ok := cell.(tableFormatter); ok {
+			cells[i] = f.TableString()
+		}
+	}
+	return cells
+}
+
+func renderTable(w io.Writer, data *Dataset) error {
+	if data.Title != "" {
+		fmt.Fprintln(w, data.Title)
+	}
+	table := tablewriter.NewWriter(w)
+	table.Header(data.titles())
+	for _, row := range data.Rows {
+		table.Append(formatTableRow(row))
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+	if data.Footer != "" {
+		fmt.Fprintln(w, data.Footer)
+	}
+	return nil
+}
+
+// encodeRecordJSON encodes a row as a JSON object keyed by the column keys, in column order.
+func encodeRecordJSON(columns []Column, row []any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, column := range columns {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(column.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(row[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func renderJSON(w io.Writer, data *Dataset) error {
+	// One compact record per line keeps arrays of numbers readable
+	var buf bytes.Buffer
+	buf.WriteString("[")
+	for i, row := range data.Rows {
+		record, err := encodeRecordJSON(data.Columns, row)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n  ")
+		buf.Write(record)
+	}
+	if len(data.Rows) > 0 {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("]\n")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func renderJSONL(w io.Writer, data *Dataset) error {
+	for _, row := range data.Rows {
+		record, err := encodeRecordJSON(data.Columns, row)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func renderDelimited(w io.Writer, data *Dataset, comma rune) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	if err := writer.Write(data.titles()); err != nil {
+		return err
+	}
+	for _, row := range data.Rows {
+		if err := writer.Write(formatRow(row)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func renderYAML(w io.Writer, data *Dataset) error {
+	records := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, row := range data.Rows {
+		record := &yaml.Node{Kind: yaml.MappingNode}
+		for i, column := range data.Columns {
+			value := &yaml.Node{}
+			if err := value.Encode(row[i]); err != nil {
+				return err
+			}
+			record.Content = append(record.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: column.Key},
+				value,
+			)
+		}
+		records.Content = append(records.Content, record)
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(records); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func renderMarkdown(w io.Writer, data *Dataset) error {
+	escape := strings.NewReplacer("|", `\|`, "\n", "<br>")
+	writeRow := func(cells []string) {
+		for i, cell := range cells {
+			cells[i] = escape.Replace(cell)
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+	}
+
+	if data.Title != "" {
+		fmt.Fprintf(w, "%s\n\n", data.Title)
+	}
+	writeRow(data.titles())
+	separators := make([]string, len(data.Columns))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeRow(separators)
+	for _, row := range data.Rows {
+		writeRow(formatRow(row))
+	}
+	if data.Footer != "" {
+		fmt.Fprintf(w, "\n%s\n", data.Footer)
+	}
+	return nil
+}
+
+func renderPlain(w io.Writer, data *Dataset) error {
+	for _, row := range data.Rows {
+		cells := make([]string, 0, len(row))
+		for i, cell := range row {
+			if data.Columns[i].Index {
+				continue
+			}
+			cells = append(cells, formatCell(cell))
+		}
+		if _, err := fmt.Fprintln(w, strings.Join(cells, "\t")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package loto_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestRender tests every output format with a small dataset
+func TestRender(t *testing.T) {
+	data := loto.PicksDataset(loto.LOTO, "", [][]int{{1, 7, 38}, {2, 8, 39}})
+
+	tests := []struct {
+		format loto.Format
+		want   string
+	}{
+		{
+			format: loto.JSON,
+			want:   "[\n  {\"no\":1,\"result\":[1,7,38]},\n  {\"no\":2,\"result\":[2,8,39]}\n]\n",
+		},
+		{
+			format: loto.JSONL,
+			want:   "{\"no\":1,\"result\":[1,7,38]}\n{\"no\":2,\"result\":[2,8,39]}\n",
+		},
+		{
+			format: loto.CSV,
+			want:   "No,Result\n1,\"01, 07, 38\"\n2,\"02, 08, 39\"\n",
+		},
+		{
+			format: loto.TSV,
+			want:   "No\tResult\n1\t01, 07, 38\n2\t02, 08, 39\n",
+		},
+		{
+			format: loto.YAML,
+			want:   "- no: 1\n  result: [1, 7, 38]\n- no: 2\n  result: [2, 8, 39]\n",
+		},
+		{
+			format: loto.MARKDOWN,
+			want:   "| No | Result |\n| --- | --- |\n| 1 | 01, 07, 38 |\n| 2 | 02, 08, 39 |\n",
+		},
+		{
+			format: loto.PLAIN,
+			want:   "01, 07, 38\n02, 08, 39\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := loto.Render(&buf, tt.format, data); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := loto.Render(&buf, loto.TABLE, data); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if !strings.Contains(buf.String(), "01, 07, 38") {
+			t.Errorf("Render() = %q, want it to contain the formatted result", buf.String())
+		}
+	})
+
+	t.Run("footer", func(t *testing.T) {
+		footed := *data
+		footed.Footer = "2 lines, ¥400"
+		var buf bytes.Buffer
+		if err := loto.Render(&buf, loto.MARKDOWN, &footed); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if !strings.HasSuffix(buf.String(), "\n\n2 lines, ¥400\n") {
+			t.Errorf("Render() = %q, want it to end with the footer", buf.String())
+		}
+
+		buf.Reset()
+		if err := loto.Render(&buf, loto.CSV, &footed); err != nil {
+			t.Fatalf("Render() error = %v", err)
+		}
+		if strings.Contains(buf.String(), "¥400") {
+			t.Errorf("Render() = %q, want no footer in csv", buf.String())
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		if err := loto.Render(&bytes.Buffer{}, loto.Format("xml"), data); err == nil {
+			t.Error("Render() error = nil, want error")
+		}
+	})
+}
+
+// TestRender_Nil tests that nil cells are rendered as "-" in text formats and null in structured formats
+func TestRender_Nil(t *testing.T) {
+	data := &loto.Dataset{
+		Columns: []loto.Column{{Key: "prize", Title: "Prize"}},
+	}
+	data.Append(nil)
+
+	var buf bytes.Buffer
+	if err := loto.Render(&buf, loto.PLAIN, data); err != nil || buf.String() != "-\n" {
+		t.Errorf("Render(plain) = %q, %v, want \"-\\n\"", buf.String(), err)
+	}
+	buf.Reset()
+	if err := loto.Render(&buf, loto.JSONL, data); err != nil || buf.String() != "{\"prize\":null}\n" {
+		t.Errorf("Render(jsonl) = %q, %v, want {\"prize\":null}", buf.String(), err)
+	}
+}
+
+// TestCostSummary tests that the line count is pluralized and the cost is left out if it is unknown
+func TestCostSummary(t *testing.T) {
+	tests := []struct {
+		lines int
+		cost  int
+		want  string
+	}{
+		{1, 200, "1 line, ¥200"},
+		{5, 1000, "5 lines, ¥1,000"},
+		{0, 0, "0 lines"},
+		{1, 0, "1 line"},
+	}
+
+	for _, tt := range tests {
+		if got := loto.CostSummary(tt.lines, tt.cost); got != tt.want {
+			t.Errorf("CostSummary(%d, %d) = %q, want %q", tt.lines, tt.cost, got, tt.want)
+		}
+	}
+	if got := loto.Plural(1, "renban pack"); got != "1 renban pack" {
+		t.Errorf("Plural() = %q, want %q", got, "1 renban pack")
+	}
+	if got := loto.Plural(100000, "draw"); got != "100,000 draws" {
+		t.Errorf("Plural() = %q, want %q", got, "100,000 draws")
+	}
+}
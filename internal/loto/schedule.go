@@ -0,0 +1,238 @@
+package loto
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+	"time"
+)
+
+// JST is Japan Standard Time, the time zone of every draw.
+var JST = time.FixedZone("JST", 9*60*60)
+
+// scheduleDateLayout is the layout of the dates of a schedule.
+const scheduleDateLayout = "2006-01-02"
+
+// Schedule describes when the draws of a lottery are held, in JST.
+// Draws are held on the weekdays of the schedule, national holidays included,
+// except during the year-end break (see InYearEndBreak) and on the cancelled dates.
+type Schedule struct {
+	Weekdays   []string          `json:"weekdays" yaml:"weekdays" toml:"weekdays"`          // Days of the week with a draw (e.g., "mon", "thu")
+	DrawTime   string            `json:"draw_time" yaml:"draw_time" toml:"draw_time"`       // Time of the draw (e.g., "18:45")
+	SalesClose string            `json:"sales_close" yaml:"sales_close" toml:"sales_close"` // Time that sales close on the draw day (e.g., "18:30")
+	Reference  ScheduleReference `json:"reference" yaml:"reference" toml:"reference"`       // A past draw that the draw numbers are counted from
+	Cancelled  []string          `json:"cancelled" yaml:"cancelled" toml:"cancelled"`       // Dates without a draw besides the year-end break (e.g., "2026-05-04")
+}
+
+// ScheduleReference is a past draw that the draw numbers of a schedule are counted from.
+// The schedule must not have changed since the draw.
+type ScheduleReference struct {
+	Number int    `json:"number" yaml:"number" toml:"number"` // Draw number (回号)
+	Date   string `json:"date" yaml:"date" toml:"date"`       // Draw date (e.g., "2013-04-05")
+}
+
+// ScheduledDraw is an upcoming draw of a lottery.
+type ScheduledDraw struct {
+	Number     int       // Draw number (回号)
+	Time       time.Time // Time of the draw in JST
+	SalesClose time.Time // Time that sales close in JST
+}
+
+// String returns the draw number and the time of the draw (e.g., "draw 1234 on Mon 2026-10-19 18:45 JST").
+func (d ScheduledDraw) String() string {
+	return fmt.Sprintf("draw %d on %s", d.Number, d.Time.Format("Mon 2006-01-02 15:04 MST"))
+}
+
+// Validate checks that the schedule has weekdays, valid times and a valid reference draw on one of its weekdays.
+func (s Schedule) Validate() error {
+	weekdays, err := s.weekdays()
+	if err != nil {
+		return err
+	}
+	if len(weekdays) == 0 {
+		return fmt.Errorf("schedule: no weekdays")
+	}
+	drawTime, err := parseClock(s.DrawTime)
+	if err != nil {
+		return fmt.Errorf("schedule: draw time: %w", err)
+	}
+	salesClose, err := parseClock(s.SalesClose)
+	if err != nil {
+		return fmt.Errorf("schedule: sales close: %w", err)
+	}
+	if salesClose > drawTime {
+		return fmt.Errorf("schedule: sales close %s is after the draw time %s", s.SalesClose, s.DrawTime)
+	}
+	if s.Reference.Number <= 0 {
+		return fmt.Errorf("schedule: reference draw number must be positive, got %d", s.Reference.Number)
+	}
+	date, err := time.ParseInLocation(scheduleDateLayout, s.Reference.Date, JST)
+	if err != nil {
+		return fmt.Errorf("schedule: invalid reference date: %q. It must be like 2013-04-05", s.Reference.Date)
+	}
+	if !s.HasDraw(date) {
+		return fmt.Errorf("schedule: no draw is held on the reference date %s", s.Reference.Date)
+	}
+	if _, err := s.cancelled(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// HasDraw reports whether a draw is held on the date of t in JST.
+func (s Schedule) HasDraw(t time.Time) bool {
+	weekdays, _ := s.weekdays()
+	return slices.Contains(weekdays, t.In(JST).Weekday()) && !InYearEndBreak(t) && !slices.Contains(s.Cancelled, t.In(JST).Format(scheduleDateLayout))
+}
+
+// InYearEndBreak reports whether the date of t in JST is in the year-end break
+// from December 31 to January 3, when no draw is held.
+func InYearEndBreak(t time.Time) bool {
+	t = t.In(JST)
+	return (t.Month() == time.December && t.Day() == 31) || (t.Month() == time.January && t.Day() <= 3)
+}
+
+// Next returns the next n draws held after t, with their draw numbers counted from the reference draw.
+// The schedule is assumed to be valid.
+func (s Schedule) Next(t time.Time, n int) []ScheduledDraw {
+	draws := make([]ScheduledDraw, 0, n)
+	for draw := range s.upcoming(t) {
+		if len(draws) == n {
+			break
+		}
+		draws = append(draws, draw)
+	}
+	return draws
+}
+
+// Until returns the draws held after t up to and including until,
+// with their draw numbers counted from the reference draw. The schedule is assumed to be valid.
+func (s Schedule) Until(t, until time.Time) []ScheduledDraw {
+	var draws []ScheduledDraw
+	for draw := range s.upcoming(t) {
+		if draw.Time.After(until) {
+			break
+		}
+		draws = append(draws, draw)
+	}
+	return draws
+}
+
+// upcoming returns the endless sequence of the draws held after t.
+func (s Schedule) upcoming(t time.Time) iter.Seq[ScheduledDraw] {
+	drawTime, _ := parseClock(s.DrawTime)
+	salesClose, _ := parseClock(s.SalesClose)
+	reference, _ := time.ParseInLocation(scheduleDateLayout, s.Reference.Date, JST)
+
+	t = t.In(JST)
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, JST)
+	return func(yield func(ScheduledDraw) bool) {
+		number := s.Reference.Number + s.countDraws(reference, start)
+		if s.HasDraw(start) {
+			// The draw of the day is counted and may be over
+			number--
+		}
+		for day := start; ; day = day.AddDate(0, 0, 1) {
+			if !s.HasDraw(day) {
+				continue
+			}
+			number++
+			draw := ScheduledDraw{
+				Number:     number,
+				Time:       day.Add(drawTime),
+				SalesClose: day.Add(salesClose),
+			}
+			if draw.Time.After(t) && !yield(draw) {
+				return
+			}
+		}
+	}
+}
+
+// countDraws returns the number of draws held after from up to and including to,
+// or minus the number of draws held after to up to and including from if to is before from.
+// Both are midnights in JST. The draws of the whole weeks are counted by their weekdays,
+// the remaining days one by one, and then the days of the year-end breaks and the cancelled dates are taken out.
+func (s Schedule) countDraws(from, to time.Time) int {
+	sign := 1
+	if to.Before(from) {
+		from, to = to, from
+		sign = -1
+	}
+	weekdays, _ := s.weekdays()
+	slices.Sort(weekdays)
+	weekdays = slices.Compact(weekdays)
+	isDrawDay := func(day time.Time) bool {
+		return day.After(from) && !day.After(to) && slices.Contains(weekdays, day.Weekday())
+	}
+
+	days := int(to.Sub(from) / (24 * time.Hour))
+	count := days / 7 * len(weekdays)
+	for day := from.AddDate(0, 0, days/7*7+1); !day.After(to); day = day.AddDate(0, 0, 1) {
+		if isDrawDay(day) {
+			count++
+		}
+	}
+	for year := from.Year(); year <= to.Year(); year++ {
+		for _, day := range []time.Time{
+			time.Date(year, time.January, 1, 0, 0, 0, 0, JST),
+			time.Date(year, time.January, 2, 0, 0, 0, 0, JST),
+			time.Date(year, time.January, 3, 0, 0, 0, 0, JST),
+			time.Date(year, time.December, 31, 0, 0, 0, 0, JST),
+		} {
+			if isDrawDay(day) {
+				count--
+			}
+		}
+	}
+	cancelled, _ := s.cancelled()
+	for _, day := range cancelled {
+		if isDrawDay(day) && !InYearEndBreak(day) {
+			count--
+		}
+	}
+	return sign * count
+}
+
+// cancelled returns the distinct cancelled dates of the schedule.
+func (s Schedule) cancelled() ([]time.Time, error) {
+	var dates []time.Time
+	for _, value := range s.Cancelled {
+		date, err := time.ParseInLocation(scheduleDateLayout, value, JST)
+		if err != nil {
+			return nil, fmt.Errorf("schedule: invalid cancelled date: %q. It must be like 2026-05-04", value)
+		}
+		if !slices.ContainsFunc(dates, date.Equal) {
+			dates = append(dates, date)
+		}
+	}
+	return dates, nil
+}
+
+// weekdays returns the weekdays of the schedule.
+func (s Schedule) weekdays() ([]time.Weekday, error) {
+	weekdays := make([]time.Weekday, 0, len(s.Weekdays))
+	for _, name := range s.Weekdays {
+		i := slices.IndexFunc(weekdayNames, func(w string) bool {
+			return strings.HasPrefix(w, strings.ToLower(name)) && len(name) >= 3
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("schedule: invalid weekday: %q. It must be like mon or monday", name)
+		}
+		weekdays = append(weekdays, time.Weekday(i))
+	}
+	return weekdays, nil
+}
+
+// weekdayNames are the names of the weekdays, from Sunday.
+var weekdayNames = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// parseClock parses a time of day such as "18:45" into the duration since midnight.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time: %q. It must be like 18:45", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
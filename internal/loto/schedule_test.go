@@ -0,0 +1,258 @@
+package loto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestSchedule_Next tests the draws and draw numbers of a schedule
+func TestSchedule_Next(t *testing.T) {
+	weekly := loto.Schedule{
+		Weekdays:   []string{"fri"},
+		DrawTime:   "18:45",
+		SalesClose: "18:30",
+		Reference:  loto.ScheduleReference{Number: 100, Date: "2021-12-10"},
+	}
+	jst := func(s string) time.Time {
+		parsed, err := time.ParseInLocation("2006-01-02 15:04", s, loto.JST)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return parsed
+	}
+
+	tests := []struct {
+		name        string
+		schedule    loto.Schedule
+		from        time.Time
+		wantNumbers []int
+		wantDates   []string
+	}{
+		{
+			name:        "before the draw of the day",
+			schedule:    weekly,
+			from:        jst("2021-12-10 18:00"),
+			wantNumbers: []int{100, 101},
+			wantDates:   []string{"2021-12-10", "2021-12-17"},
+		},
+		{
+			name:        "after the draw of the day",
+			schedule:    weekly,
+			from:        jst("2021-12-10 19:00"),
+			wantNumbers: []int{101, 102},
+			wantDates:   []string{"2021-12-17", "2021-12-24"},
+		},
+		{
+			name:        "year-end break",
+			schedule:    weekly,
+			from:        jst("2021-12-25 12:00"),
+			wantNumbers: []int{103, 104},
+			wantDates:   []string{"2022-01-07", "2022-01-14"},
+		},
+		{
+			name:        "before the reference draw",
+			schedule:    weekly,
+			from:        jst("2021-11-20 12:00"),
+			wantNumbers: []int{98, 99, 100},
+			wantDates:   []string{"2021-11-26", "2021-12-03", "2021-12-10"},
+		},
+		{
+			name: "cancelled draw",
+			schedule: loto.Schedule{
+				Weekdays:   []string{"fri"},
+				DrawTime:   "18:45",
+				SalesClose: "18:30",
+				Reference:  loto.ScheduleReference{Number: 100, Date: "2021-12-10"},
+				Cancelled:  []string{"2021-12-17", "2021-12-19"},
+			},
+			from:        jst("2021-12-10 19:00"),
+			wantNumbers: []int{101, 102},
+			wantDates:   []string{"2021-12-24", "2022-01-07"},
+		},
+		{
+			name: "weekdays across the new year",
+			schedule: loto.Schedule{
+				Weekdays:   []string{"mon", "tue", "wed", "thu", "friday"},
+				DrawTime:   "18:45",
+				SalesClose: "18:30",
+				Reference:  loto.ScheduleReference{Number: 10, Date: "2024-12-30"},
+			},
+			from:        time.Date(2024, 12, 30, 12, 0, 0, 0, time.UTC), // 21:00 JST
+			wantNumbers: []int{11, 12},
+			wantDates:   []string{"2025-01-06", "2025-01-07"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.schedule.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			draws := tt.schedule.Next(tt.from, len(tt.wantNumbers))
+			if len(draws) != len(tt.wantNumbers) {
+				t.Fatalf("Next() = %v, want %d draws", draws, len(tt.wantNumbers))
+			}
+			for i, draw := range draws {
+				if draw.Number != tt.wantNumbers[i] || draw.Time.Format(time.DateOnly) != tt.wantDates[i] {
+					t.Errorf("Next()[%d] = %v, want draw %d on %s", i, draw, tt.wantNumbers[i], tt.wantDates[i])
+				}
+				if got := draw.Time.Format("15:04 MST"); got != "18:45 JST" {
+					t.Errorf("Next()[%d] time = %s, want 18:45 JST", i, got)
+				}
+				if got := draw.SalesClose.Format("15:04 MST"); got != "18:30 JST" {
+					t.Errorf("Next()[%d] sales close = %s, want 18:30 JST", i, got)
+				}
+			}
+		})
+	}
+}
+
+// TestSchedule_Validate tests that invalid schedules are rejected
+func TestSchedule_Validate(t *testing.T) {
+	valid := loto.Schedule{
+		Weekdays:   []string{"mon", "thu"},
+		DrawTime:   "18:45",
+		SalesClose: "18:30",
+		Reference:  loto.ScheduleReference{Number: 1, Date: "2024-01-04"},
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *loto.Schedule)
+	}{
+		{"no weekdays", func(s *loto.Schedule) { s.Weekdays = nil }},
+		{"invalid weekday", func(s *loto.Schedule) { s.Weekdays = []string{"mo"} }},
+		{"invalid draw time", func(s *loto.Schedule) { s.DrawTime = "6:45pm" }},
+		{"sales close after the draw", func(s *loto.Schedule) { s.SalesClose = "19:00" }},
+		{"non-positive reference number", func(s *loto.Schedule) { s.Reference.Number = 0 }},
+		{"invalid reference date", func(s *loto.Schedule) { s.Reference.Date = "2024/01/04" }},
+		{"reference date without a draw", func(s *loto.Schedule) { s.Reference.Date = "2024-01-05" }},
+		{"reference date in the year-end break", func(s *loto.Schedule) { s.Reference.Date = "2024-01-01" }},
+		{"cancelled reference date", func(s *loto.Schedule) { s.Cancelled = []string{"2024-01-04"} }},
+		{"invalid cancelled date", func(s *loto.Schedule) { s.Cancelled = []string{"2024/02/01"} }},
+	}
+
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := valid
+			schedule.Weekdays = append([]string(nil), valid.Weekdays...)
+			tt.modify(&schedule)
+			if err := schedule.Validate(); err == nil {
+				t.Error("Validate() error = nil, want error")
+			}
+		})
+	}
+}
+
+// TestSchedule_Next_DrawNumbers tests that the draw numbers count every draw day since the reference draw, years away
+func TestSchedule_Next_DrawNumbers(t *testing.T) {
+	schedule := loto.Schedule{
+		Weekdays:   []string{"mon", "thu", "sat"},
+		DrawTime:   "18:45",
+		SalesClose: "18:30",
+		Reference:  loto.ScheduleReference{Number: 500, Date: "2015-06-04"},
+		Cancelled:  []string{"2012-05-03", "2016-05-05", "2016-05-05", "2019-12-31", "2020-09-22"},
+	}
+	if err := schedule.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	reference := time.Date(2015, 6, 4, 0, 0, 0, 0, loto.JST)
+
+	for _, date := range []time.Time{
+		time.Date(2010, 1, 5, 0, 0, 0, 0, loto.JST),
+		time.Date(2015, 5, 30, 0, 0, 0, 0, loto.JST),
+		time.Date(2015, 6, 8, 0, 0, 0, 0, loto.JST),
+		time.Date(2021, 1, 4, 0, 0, 0, 0, loto.JST),
+		time.Date(2026, 10, 19, 0, 0, 0, 0, loto.JST),
+	} {
+		// Count the draw days one by one
+		want := 500
+		for day := reference; day.Before(date); day = day.AddDate(0, 0, 1) {
+			if schedule.HasDraw(day) {
+				want++
+			}
+		}
+		for day := reference.AddDate(0, 0, -1); !day.Before(date); day = day.AddDate(0, 0, -1) {
+			if schedule.HasDraw(day) {
+				want--
+			}
+		}
+
+		draws := schedule.Next(date, 1)
+		if len(draws) != 1 || draws[0].Number != want {
+			t.Errorf("Next(%s) = %v, want draw %d", date.Format(time.DateOnly), draws, want)
+		}
+	}
+}
+
+// TestBuiltinSchedules tests that every built-in game has a valid schedule on its draw days
+func TestBuiltinSchedules(t *testing.T) {
+	tests := map[loto.LotteryType]time.Weekday{
+		loto.LOTO_6:    time.Monday,
+		loto.LOTO_7:    time.Friday,
+		loto.LOTO_MINI: time.Tuesday,
+		loto.NUMBERS_3: time.Monday,
+		loto.NUMBERS_4: time.Monday,
+		loto.BINGO_5:   time.Wednesday,
+	}
+	// Monday, October 19, 2026
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, loto.JST)
+
+	for lotteryType, weekday := range tests {
+		config, _ := loto.Lookup(lotteryType)
+		if config.Schedule == nil {
+			t.Errorf("%s has no schedule", lotteryType)
+			continue
+		}
+		if err := config.Schedule.Validate(); err != nil {
+			t.Errorf("%s schedule Validate() error = %v", lotteryType, err)
+		}
+		draws := config.Schedule.Next(from, 2)
+		if got := draws[0].Time.Weekday(); got != weekday {
+			t.Errorf("%s next draw on %v, want %v", lotteryType, got, weekday)
+		}
+		if draws[1].Number != draws[0].Number+1 {
+			t.Errorf("%s draw numbers = %d, %d, want consecutive", lotteryType, draws[0].Number, draws[1].Number)
+		}
+	}
+}
+
+// TestSchedule_Until tests that the draws up to a time are returned
+func TestSchedule_Until(t *testing.T) {
+	schedule := loto.Schedule{
+		Weekdays:   []string{"mon", "thu"},
+		DrawTime:   "18:45",
+		SalesClose: "18:30",
+		Reference:  loto.ScheduleReference{Number: 1, Date: "2024-01-04"},
+	}
+	from := time.Date(2024, 1, 4, 19, 0, 0, 0, loto.JST)
+
+	tests := []struct {
+		name        string
+		until       time.Time
+		wantNumbers []int
+	}{
+		{"no draw", time.Date(2024, 1, 8, 18, 0, 0, 0, loto.JST), nil},
+		{"including the draw at the time", time.Date(2024, 1, 8, 18, 45, 0, 0, loto.JST), []int{2}},
+		{"two weeks", from.AddDate(0, 0, 14), []int{2, 3, 4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draws := schedule.Until(from, tt.until)
+			if len(draws) != len(tt.wantNumbers) {
+				t.Fatalf("Until() = %v, want %d draws", draws, len(tt.wantNumbers))
+			}
+			for i, draw := range draws {
+				if draw.Number != tt.wantNumbers[i] {
+					t.Errorf("Until()[%d] = %v, want draw %d", i, draw, tt.wantNumbers[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package loto
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+
+	"github.com/kawana77b/loto/internal/util"
+)
+
+// SimulationChunk is the number of draws that a simulation plays with the same random source.
+// The chunks do not depend on the number of workers, so the same seed always gives the same result.
+const SimulationChunk = 10000
+
+// PrizeTally holds how many lines won a prize in a simulation and how much they won.
+type PrizeTally struct {
+	Name     string // Name of the prize (e.g., "1st", "straight")
+	Hits     int64  // Number of winning lines
+	Winnings int64  // Total prize in yen
+}
+
+// SimulationResult holds the outcome of playing the same tickets in many simulated draws.
+type SimulationResult struct {
+	Draws    int          // Number of simulated draws
+	Tickets  int          // Number of tickets played in every draw
+	Cost     int64        // Total price of the lines in yen
+	Winnings int64        // Total prize in yen
+	Prizes   []PrizeTally // Tallies of every prize, from the highest
+}
+
+// Lines returns the number of lines played in the simulation.
+func (r SimulationResult) Lines() int64 {
+	return int64(r.Draws) * int64(r.Tickets)
+}
+
+// Return returns the winnings as a share of the cost (0 if the cost is unknown).
+func (r SimulationResult) Return() float64 {
+	if r.Cost == 0 {
+		return 0
+	}
+	return float64(r.Winnings) / float64(r.Cost)
+}
+
+// simulation holds the settings of a simulation.
+type simulation struct {
+	workers  int
+	progress func(done, total int)
+}
+
+// SimulationOption configures a simulation.
+type SimulationOption func(*simulation)
+
+// WithSimulationWorkers sets the number of goroutines that play the draws (GOMAXPROCS by default).
+func WithSimulationWorkers(n int) SimulationOption {
+	return func(s *simulation) {
+		s.workers = n
+	}
+}
+
+// WithSimulationProgress makes the simulation call fn with the number of draws done so far
+// each time a chunk of draws is done. fn is never called concurrently.
+func WithSimulationProgress(fn func(done, total int)) SimulationOption {
+	return func(s *simulation) {
+		s.progress = fn
+	}
+}
+
+// chunkTally holds the outcome of a chunk of draws.
+type chunkTally struct {
+	draws    int
+	hits     []int64
+	winnings []int64
+}
+
+// Simulate plays the tickets in the given number of simulated official draws, including the bonus numbers,
+// and tallies the prizes they win with the prizes of the tiers (loto) or NumbersPrize (numbers).
+// The tickets are assumed to be picked by the game. The draws are split into chunks of SimulationChunk draws,
+// each with its own random source seeded from the source of the game, and the chunks are played in parallel.
+func (l *LotteryGame) Simulate(tickets [][]int, draws int, opts ...SimulationOption) (SimulationResult, error) {
+	s := &simulation{workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if len(tickets) == 0 {
+		return SimulationResult{}, fmt.Errorf("no tickets to simulate")
+	}
+	if draws <= 0 {
+		return SimulationResult{}, fmt.Errorf("invalid number of draws: %d", draws)
+	}
+	if s.workers <= 0 {
+		return SimulationResult{}, fmt.Errorf("invalid number of workers: %d", s.workers)
+	}
+	names, evaluate, err := l.evaluator()
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	// Every chunk gets its own stream of the same seed
+	seed := util.Uint64(l.src)
+	chunks := (draws + SimulationChunk - 1) / SimulationChunk
+	jobs := make(chan int)
+	results := make(chan chunkTally)
+	for range min(s.workers, chunks) {
+		go func() {
+			for chunk := range jobs {
+				results <- l.simulateChunk(tickets, chunk, draws, seed, len(names), evaluate)
+			}
+		}()
+	}
+	go func() {
+		for chunk := range chunks {
+			jobs <- chunk
+		}
+		close(jobs)
+	}()
+
+	result := SimulationResult{
+		Draws:   draws,
+		Tickets: len(tickets),
+		Cost:    int64(draws) * int64(len(tickets)) * int64(l.config.LinePrice(l.bet)),
+		Prizes:  make([]PrizeTally, len(names)),
+	}
+	for i, name := range names {
+		result.Prizes[i].Name = name
+	}
+	done := 0
+	for range chunks {
+		tally := <-results
+		for i := range result.Prizes {
+			result.Prizes[i].Hits += tally.hits[i]
+			result.Prizes[i].Winnings += tally.winnings[i]
+			result.Winnings += tally.winnings[i]
+		}
+		done += tally.draws
+		if s.progress != nil {
+			s.progress(done, draws)
+		}
+	}
+	return result, nil
+}
+
+// simulateChunk plays the tickets in the draws of the chunk.
+func (l *LotteryGame) simulateChunk(tickets [][]int, chunk, draws int, seed uint64, prizes int, evaluate prizeEvaluator) chunkTally {
+	boxes := drawBoxes(l.config, rand.NewPCG(seed, uint64(chunk)))
+	tally := chunkTally{
+		draws:    min(SimulationChunk, draws-chunk*SimulationChunk),
+		hits:     make([]int64, prizes),
+		winnings: make([]int64, prizes),
+	}
+	for range tally.draws {
+		draw := drawFrom(l.config, boxes)
+		for _, ticket := range tickets {
+			if i, prize := evaluate(ticket, draw); i >= 0 {
+				tally.hits[i]++
+				tally.winnings[i] += int64(prize)
+			}
+		}
+	}
+	return tally
+}
+
+// prizeEvaluator returns the index of the prize that the ticket wins in the draw
+// and the prize in yen, or -1 if the ticket wins nothing.
+type prizeEvaluator func(ticket []int, draw Draw) (int, int)
+
+// evaluator returns the names of the prizes of the game, from the highest, and their evaluator.
+func (l *LotteryGame) evaluator() ([]string, prizeEvaluator, error) {
+	if l.config.Category == NUMBERS {
+		names := l.bet.Prizes()
+		return names, func(ticket []int, draw Draw) (int, int) {
+			result := EvaluateNumbers(l.bet, ticket, draw.Numbers)
+			if !result.Won() {
+				return -1, 0
+			}
+			return slices.Index(names, result.Prize), NumbersPrize(l.config, result)
+		}, nil
+	}
+
+	if len(l.config.Tiers) == 0 {
+		return nil, nil, fmt.Errorf("prize tiers are not defined for the game")
+	}
+	names := make([]string, len(l.config.Tiers))
+	for i, tier := range l.config.Tiers {
+		names[i] = tier.Name()
+	}
+	return names, func(ticket []int, draw Draw) (int, int) {
+		result := Evaluate(l.config, ticket, draw)
+		if !result.Won() {
+			return -1, 0
+		}
+		return result.Tier.Rank - 1, result.Tier.Prize
+	}, nil
+}
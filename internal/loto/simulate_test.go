@@ -0,0 +1,150 @@
+package loto_test
+
+import (
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestLotteryGame_Simulate tests that simulations are deterministic and tally every prize
+func TestLotteryGame_Simulate(t *testing.T) {
+	simulate := func(workers int) loto.SimulationResult {
+		lottery, err := loto.NewLottery(loto.LOTO_MINI, loto.WithSeed(1))
+		if err != nil {
+			t.Fatalf("NewLottery() error = %v", err)
+		}
+		tickets, err := lottery.PickN(5)
+		if err != nil {
+			t.Fatalf("PickN() error = %v", err)
+		}
+		result, err := lottery.Simulate(tickets, 25000, loto.WithSimulationWorkers(workers))
+		if err != nil {
+			t.Fatalf("Simulate() error = %v", err)
+		}
+		return result
+	}
+
+	result := simulate(1)
+	if got := simulate(4); got.Winnings != result.Winnings || got.Prizes[3] != result.Prizes[3] {
+		t.Errorf("Simulate() with 4 workers = %+v, want %+v", got, result)
+	}
+	if result.Lines() != 125000 || result.Cost != 125000*200 {
+		t.Errorf("Simulate() lines = %d, cost = %d, want 125000 lines for ¥25,000,000", result.Lines(), result.Cost)
+	}
+	if len(result.Prizes) != 4 || result.Prizes[0].Name != "1st" {
+		t.Fatalf("Simulate() prizes = %+v, want the 4 tiers of miniloto", result.Prizes)
+	}
+
+	var winnings int64
+	for _, prize := range result.Prizes {
+		winnings += prize.Winnings
+	}
+	if winnings != result.Winnings {
+		t.Errorf("Simulate() winnings = %d, want the sum of the prizes %d", result.Winnings, winnings)
+	}
+	// 3 matches of miniloto win about 1 in 60 lines
+	if hits := result.Prizes[3].Hits; hits < 1500 || hits > 2700 {
+		t.Errorf("Simulate() 4th prize hits = %d, want about 2000", hits)
+	}
+}
+
+// TestLotteryGame_Simulate_Progress tests that the progress reaches every draw
+func TestLotteryGame_Simulate_Progress(t *testing.T) {
+	lottery, _ := loto.NewLottery(loto.NUMBERS_3, loto.WithSeed(1), loto.WithBet(loto.SET))
+	tickets, _ := lottery.PickN(3)
+
+	calls, last := 0, 0
+	_, err := lottery.Simulate(tickets, 2*loto.SimulationChunk+1, loto.WithSimulationProgress(func(done, total int) {
+		calls++
+		if done <= last || total != 2*loto.SimulationChunk+1 {
+			t.Errorf("progress(%d, %d) after %d", done, total, last)
+		}
+		last = done
+	}))
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+	if calls != 3 || last != 2*loto.SimulationChunk+1 {
+		t.Errorf("progress called %d times up to %d, want 3 times up to %d", calls, last, 2*loto.SimulationChunk+1)
+	}
+}
+
+// TestLotteryGame_Simulate_Invalid tests that invalid simulations are rejected
+func TestLotteryGame_Simulate_Invalid(t *testing.T) {
+	lottery, _ := loto.NewLottery(loto.LOTO_6)
+	tickets := [][]int{{1, 2, 3, 4, 5, 6}}
+
+	if _, err := lottery.Simulate(nil, 10); err == nil {
+		t.Error("Simulate() without tickets error = nil, want error")
+	}
+	if _, err := lottery.Simulate(tickets, 0); err == nil {
+		t.Error("Simulate() without draws error = nil, want error")
+	}
+	if _, err := lottery.Simulate(tickets, 10, loto.WithSimulationWorkers(0)); err == nil {
+		t.Error("Simulate() without workers error = nil, want error")
+	}
+}
+
+// TestNumbersPrize tests the standard prizes of Numbers
+func TestNumbersPrize(t *testing.T) {
+	numbers3, _ := loto.Lookup(loto.NUMBERS_3)
+	numbers4, _ := loto.Lookup(loto.NUMBERS_4)
+
+	tests := []struct {
+		name    string
+		config  loto.LotteryConfig
+		bet     loto.BetType
+		ticket  []int
+		winning []int
+		want    int
+	}{
+		{"numbers3 straight", numbers3, loto.STRAIGHT, []int{1, 2, 3}, []int{1, 2, 3}, 90000},
+		{"numbers3 6-way box", numbers3, loto.BOX, []int{1, 2, 3}, []int{3, 2, 1}, 15000},
+		{"numbers3 3-way box", numbers3, loto.BOX, []int{1, 1, 3}, []int{1, 3, 1}, 30000},
+		{"numbers3 mini", numbers3, loto.MINI, []int{2, 3}, []int{1, 2, 3}, 9000},
+		{"numbers4 straight", numbers4, loto.STRAIGHT, []int{1, 2, 3, 4}, []int{1, 2, 3, 4}, 900000},
+		{"numbers4 24-way box", numbers4, loto.BOX, []int{1, 2, 3, 4}, []int{4, 3, 2, 1}, 37500},
+		{"no prize", numbers3, loto.STRAIGHT, []int{1, 2, 3}, []int{3, 2, 1}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := loto.EvaluateNumbers(tt.bet, tt.ticket, tt.winning)
+			if got := loto.NumbersPrize(tt.config, result); got != tt.want {
+				t.Errorf("NumbersPrize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNumbersPrize_Set tests the prizes of set tickets, which are two units and add up the prizes of a straight and a box unit
+func TestNumbersPrize_Set(t *testing.T) {
+	numbers3, _ := loto.Lookup(loto.NUMBERS_3)
+	numbers4, _ := loto.Lookup(loto.NUMBERS_4)
+
+	tests := []struct {
+		name    string
+		config  loto.LotteryConfig
+		ticket  []int
+		winning []int
+		want    int
+	}{
+		{"numbers3 set-straight", numbers3, []int{1, 2, 3}, []int{1, 2, 3}, 105000},
+		{"numbers3 set-box", numbers3, []int{1, 2, 3}, []int{3, 2, 1}, 15000},
+		{"numbers4 set-straight", numbers4, []int{1, 2, 3, 4}, []int{1, 2, 3, 4}, 937500},
+		{"numbers4 set-box", numbers4, []int{1, 2, 3, 4}, []int{4, 3, 2, 1}, 37500},
+		{"no prize", numbers3, []int{1, 2, 3}, []int{4, 5, 6}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := loto.EvaluateNumbers(loto.SET, tt.ticket, tt.winning)
+			if got := loto.NumbersPrize(tt.config, result); got != tt.want {
+				t.Errorf("NumbersPrize() = %v, want %v", got, tt.want)
+			}
+			if got := tt.config.LinePrice(loto.SET); got != 2*tt.config.Price {
+				t.Errorf("LinePrice() = %v, want %v", got, 2*tt.config.Price)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package loto
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/kawana77b/loto/internal/util"
+)
+
+// spreadCandidates is the number of candidate tickets compared for each ticket of a spread set.
+const spreadCandidates = 200
+
+// WithSpread makes PickN spread the numbers across the set so that each number is used about equally.
+// It selects the coverage strategy, which is only available for loto types.
+func WithSpread() Option {
+	return WithStrategy(CoverageStrategy)
+}
+
+// WithMaxOverlap makes PickN pick sets in which no two tickets share more than k numbers.
+// It is taken by the coverage strategy, which is only available for loto types.
+func WithMaxOverlap(k int) Option {
+	return func(l *LotteryGame) {
+		l.maxOverlap = k
+	}
+}
+
+// validateOverlap checks that the overlap limit can be applied to the lottery.
+func (l *LotteryGame) validateOverlap() error {
+	if l.maxOverlap < len(l.include) || l.maxOverlap > l.config.Count {
+		return fmt.Errorf("invalid maximum overlap: %d. It must be between %d and %d", l.maxOverlap, len(l.include), l.config.Count)
+	}
+	return nil
+}
+
+// pickSpread picks count unique tickets that respect the overlap limit.
+// Each ticket is made of the numbers used the least by the previous tickets if it passes the filters and constraints,
+// or else it is the random candidate whose numbers were used the least.
+// The tickets found so far are returned with ErrExhausted if no candidate is found within the maximum number of attempts.
+func (l *LotteryGame) pickSpread(count int) ([][]int, error) {
+	if space := l.ResultSpace(); space.Cmp(big.NewInt(int64(count))) < 0 {
+		return nil, fmt.Errorf("%w: %d requested, but only %s are possible", ErrExhausted, count, space)
+	}
+	results := make([][]int, 0, count)
+	usage := make(map[int]int)
+	for len(results) < count {
+		// The least used numbers make the best ticket, if it passes
+		if ticket := l.leastUsed(usage); l.accepts(ticket) && l.fits(ticket, results) {
+			results = append(results, ticket)
+			for _, n := range ticket {
+				usage[n]++
+			}
+			continue
+		}
+
+		var best []int
+		bestScore, found, misses := 0, 0, 0
+		for found < spreadCandidates && misses < l.maxAttempts {
+			picked, err := l.Pick()
+			if err != nil {
+				return nil, err
+			}
+			if !l.fits(picked, results) {
+				misses++
+				continue
+			}
+			found++
+
+			score := 0
+			for _, n := range picked {
+				score += usage[n]
+			}
+			if best == nil || score < bestScore {
+				best, bestScore = picked, score
+			}
+		}
+		if best == nil {
+			return results, fmt.Errorf("%w: %d requested, but only %d were found with at most %d shared numbers", ErrExhausted, count, len(results), l.maxOverlap)
+		}
+
+		results = append(results, best)
+		for _, n := range best {
+			usage[n]++
+		}
+	}
+	return results, nil
+}
+
+// leastUsed returns the ticket made of the included numbers and the least used numbers of the pool.
+// Numbers used equally often are chosen at random.
+func (l *LotteryGame) leastUsed(usage map[int]int) []int {
+	items := util.Shuffle(l.src, l.pool.items)
+	slices.SortStableFunc(items, func(a, b int) int {
+		return cmp.Compare(usage[a], usage[b])
+	})
+	ticket := append(items[:l.config.Count-len(l.include)], l.include...)
+	slices.Sort(ticket)
+	return ticket
+}
+
+// fits reports whether the ticket is new and shares at most the maximum overlap with each of the results.
+func (l *LotteryGame) fits(ticket []int, results [][]int) bool {
+	for _, result := range results {
+		shared := countShared(ticket, result)
+		if shared == len(ticket) || shared > l.maxOverlap {
+			return false
+		}
+	}
+	return true
+}
+
+// Coverage describes how a set of loto tickets covers the numbers they are picked from.
+type Coverage struct {
+	Covered    int // Number of distinct numbers used by the tickets
+	Total      int // Number of numbers the tickets are picked from
+	MinUse     int // Fewest tickets a number is used by
+	MaxUse     int // Most tickets a number is used by
+	MaxOverlap int // Most numbers shared by two tickets
+}
+
+// MeasureCoverage measures how the tickets cover the numbers they are picked from.
+// Numbers of the tickets that are not among them, like included numbers, are not counted.
+func MeasureCoverage(numbers []int, tickets [][]int) Coverage {
+	usage := make(map[int]int)
+	for _, ticket := range tickets {
+		for _, n := range ticket {
+			usage[n]++
+		}
+	}
+
+	c := Coverage{
+		Total:  len(numbers),
+		MinUse: len(tickets),
+	}
+	for _, n := range numbers {
+		if usage[n] > 0 {
+			c.Covered++
+		}
+		c.MinUse = min(c.MinUse, usage[n])
+		c.MaxUse = max(c.MaxUse, usage[n])
+	}
+	for i := range tickets {
+		for j := i + 1; j < len(tickets); j++ {
+			c.MaxOverlap = max(c.MaxOverlap, countShared(tickets[i], tickets[j]))
+		}
+	}
+	return c
+}
+
+// Coverage measures how the tickets cover the numbers of the game,
+// without the excluded numbers and the included numbers that every ticket has.
+func (l *LotteryGame) Coverage(tickets [][]int) Coverage {
+	numbers := slices.Clone(l.pool.items)
+	slices.Sort(numbers)
+	return MeasureCoverage(numbers, tickets)
+}
+
+// String summarizes the coverage (e.g., "covers 43/43 numbers, each used 1-2 times, at most 2 shared numbers").
+func (c Coverage) String() string {
+	return fmt.Sprintf("covers %d/%d numbers, each used %s times, at most %d shared numbers",
+		c.Covered, c.Total, Range{Min: c.MinUse, Max: c.MaxUse}, c.MaxOverlap)
+}
+
+// countShared returns the number of elements of a that are also in b.
+func countShared(a, b []int) int {
+	return countFunc(a, func(n int) bool {
+		return slices.Contains(b, n)
+	})
+}
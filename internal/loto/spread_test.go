@@ -0,0 +1,122 @@
+package loto_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestLotteryGame_Spread tests that spread sets use each number about equally and respect the overlap limit
+func TestLotteryGame_Spread(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []loto.Option
+		count      int
+		maxOverlap int
+		spread     bool
+	}{
+		{name: "spread", opts: []loto.Option{loto.WithSpread()}, count: 14, maxOverlap: 6, spread: true},
+		{name: "overlap limit", opts: []loto.Option{loto.WithMaxOverlap(2)}, count: 20, maxOverlap: 2},
+		{name: "spread with overlap limit", opts: []loto.Option{loto.WithSpread(), loto.WithMaxOverlap(1)}, count: 10, maxOverlap: 1, spread: true},
+		{name: "spread with included numbers", opts: []loto.Option{loto.WithSpread(), loto.WithInclude(7), loto.WithMaxOverlap(2)}, count: 8, maxOverlap: 2},
+		{name: "spread with excluded numbers", opts: []loto.Option{loto.WithSpread(), loto.WithExclude(1, 2, 3)}, count: 7, maxOverlap: 6, spread: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := range uint64(10) {
+				lottery, err := loto.NewLottery(loto.LOTO_6, append(tt.opts, loto.WithSeed(seed))...)
+				if err != nil {
+					t.Fatalf("NewLottery() error = %v", err)
+				}
+				results, err := lottery.PickN(tt.count)
+				if err != nil {
+					t.Fatalf("PickN() error = %v", err)
+				}
+				if len(results) != tt.count {
+					t.Fatalf("PickN() length = %v, want %v", len(results), tt.count)
+				}
+
+				coverage := lottery.Coverage(results)
+				if coverage.MaxOverlap > tt.maxOverlap {
+					t.Errorf("PickN() %v, want at most %d shared numbers", coverage, tt.maxOverlap)
+				}
+				if tt.spread && coverage.MaxUse-coverage.MinUse > 1 {
+					t.Errorf("PickN() %v, want each number used about equally", coverage)
+				}
+			}
+		})
+	}
+}
+
+// TestLotteryGame_Spread_Invalid tests the errors of spread sets
+func TestLotteryGame_Spread_Invalid(t *testing.T) {
+	t.Run("too many tickets for the overlap limit", func(t *testing.T) {
+		lottery, err := loto.NewLottery(loto.LOTO_6, loto.WithMaxOverlap(0), loto.WithMaxAttempts(1000))
+		if err != nil {
+			t.Fatalf("NewLottery() error = %v", err)
+		}
+		// At most 7 tickets of 43 numbers share no numbers
+		if _, err := lottery.PickN(8); !errors.Is(err, loto.ErrExhausted) {
+			t.Errorf("PickN() error = %v, want %v", err, loto.ErrExhausted)
+		}
+	})
+
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		opts        []loto.Option
+	}{
+		{name: "numbers game", lotteryType: loto.NUMBERS_3, opts: []loto.Option{loto.WithSpread()}},
+		{name: "negative overlap", lotteryType: loto.LOTO_6, opts: []loto.Option{loto.WithMaxOverlap(-1)}},
+		{name: "overlap below the included numbers", lotteryType: loto.LOTO_6, opts: []loto.Option{loto.WithInclude(1, 2), loto.WithMaxOverlap(1)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := loto.NewLottery(tt.lotteryType, tt.opts...); err == nil {
+				t.Error("NewLottery() error = nil, want error")
+			}
+		})
+	}
+}
+
+// TestMeasureCoverage tests the MeasureCoverage function
+func TestMeasureCoverage(t *testing.T) {
+	numbers := make([]int, 31)
+	for i := range numbers {
+		numbers[i] = i + 1
+	}
+	tickets := [][]int{
+		{1, 2, 3, 4, 5},
+		{1, 2, 3, 6, 7},
+		{8, 9, 10, 11, 12},
+	}
+
+	want := loto.Coverage{Covered: 12, Total: 31, MinUse: 0, MaxUse: 2, MaxOverlap: 3}
+	got := loto.MeasureCoverage(numbers, tickets)
+	if got != want {
+		t.Errorf("MeasureCoverage() = %+v, want %+v", got, want)
+	}
+	if s := got.String(); s != "covers 12/31 numbers, each used 0-2 times, at most 3 shared numbers" {
+		t.Errorf("Coverage.String() = %q", s)
+	}
+}
+
+// TestLotteryGame_Coverage tests that the coverage of a game leaves out its included and excluded numbers
+func TestLotteryGame_Coverage(t *testing.T) {
+	lottery, err := loto.NewLottery(loto.LOTO_MINI, loto.WithInclude(1), loto.WithExclude(30, 31))
+	if err != nil {
+		t.Fatalf("NewLottery() error = %v", err)
+	}
+	tickets := [][]int{
+		{1, 2, 3, 4, 5},
+		{1, 6, 7, 8, 9},
+	}
+
+	want := loto.Coverage{Covered: 8, Total: 28, MinUse: 0, MaxUse: 1, MaxOverlap: 1}
+	if got := lottery.Coverage(tickets); got != want {
+		t.Errorf("Coverage() = %+v, want %+v", got, want)
+	}
+}
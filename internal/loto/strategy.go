@@ -0,0 +1,329 @@
+package loto
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/kawana77b/loto/internal/util"
+)
+
+// Options that a strategy can take
+const (
+	PARAM_INCLUDE     = "include"     // WithInclude
+	PARAM_EXCLUDE     = "exclude"     // WithExclude
+	PARAM_FILTERS     = "filters"     // WithFilters
+	PARAM_MAX_OVERLAP = "max-overlap" // WithMaxOverlap
+	PARAM_WEIGHTS     = "weights"     // WithWeights
+)
+
+// Strategy generates the tickets of a lottery game.
+type Strategy interface {
+	// Name of the strategy, used to select it (e.g., "uniform").
+	Name() string
+	// Short description of how the tickets are generated.
+	Description() string
+	// Whether the strategy can generate tickets for games of the category.
+	Supports(category LotteryCategory) bool
+	// Options that the strategy takes (PARAM_INCLUDE, PARAM_FILTERS, ...).
+	Params() []string
+	// Generate count unique tickets for the game.
+	PickN(game *LotteryGame, count int) ([][]int, error)
+}
+
+// strategy is a Strategy built by NewStrategy.
+type strategy struct {
+	name        string
+	description string
+	categories  []LotteryCategory
+	params      []string
+	pickN       func(game *LotteryGame, count int) ([][]int, error)
+}
+
+// NewStrategy creates a strategy that generates tickets with the pickN function.
+// Custom strategies can build on the Pick method of the game and on PickUnique.
+func NewStrategy(name, description string, categories []LotteryCategory, params []string, pickN func(game *LotteryGame, count int) ([][]int, error)) Strategy {
+	return &strategy{
+		name:        name,
+		description: description,
+		categories:  categories,
+		params:      params,
+		pickN:       pickN,
+	}
+}
+
+func (s *strategy) Name() string {
+	return s.name
+}
+
+func (s *strategy) Description() string {
+	return s.description
+}
+
+func (s *strategy) Supports(category LotteryCategory) bool {
+	return slices.Contains(s.categories, category)
+}
+
+func (s *strategy) Params() []string {
+	return s.params
+}
+
+func (s *strategy) PickN(game *LotteryGame, count int) ([][]int, error) {
+	return s.pickN(game, count)
+}
+
+// PickUnique picks count unique tickets with the Pick method of the game, like the uniform strategy.
+// See LotteryGame.PickN for the errors.
+func PickUnique(game *LotteryGame, count int) ([][]int, error) {
+	return pickN(game, count, game.maxAttempts)
+}
+
+// Built-in strategies
+var (
+	// UniformStrategy picks every possible ticket with the same probability.
+	UniformStrategy = NewStrategy("uniform",
+		"Picks uniformly from every possible ticket, drawing distinct tickets by their index",
+		[]LotteryCategory{LOTO, NUMBERS, BINGO},
+		nil,
+		func(game *LotteryGame, count int) ([][]int, error) {
+			return game.pickUniform(count)
+		},
+	)
+	// ConstrainedStrategy picks tickets uniformly among those that satisfy the constraints and filters.
+	ConstrainedStrategy = NewStrategy("constrained",
+		"Picks uniformly from the tickets that satisfy the constraints and filters, listing them when they are few",
+		[]LotteryCategory{LOTO, NUMBERS, BINGO},
+		[]string{PARAM_INCLUDE, PARAM_EXCLUDE, PARAM_FILTERS},
+		func(game *LotteryGame, count int) ([][]int, error) {
+			return game.pickConstrained(count)
+		},
+	)
+	// WeightedStrategy picks tickets with probabilities proportional to the weights of their numbers.
+	WeightedStrategy = NewStrategy("weighted",
+		"Picks each number with a probability proportional to its weight",
+		[]LotteryCategory{LOTO, NUMBERS, BINGO},
+		[]string{PARAM_WEIGHTS, PARAM_INCLUDE, PARAM_EXCLUDE, PARAM_FILTERS},
+		PickUnique,
+	)
+	// CoverageStrategy spreads the numbers across the set of tickets and limits their overlap.
+	CoverageStrategy = NewStrategy("coverage",
+		"Uses each number about equally and limits the numbers shared by two tickets",
+		[]LotteryCategory{LOTO},
+		[]string{PARAM_INCLUDE, PARAM_EXCLUDE, PARAM_FILTERS, PARAM_MAX_OVERLAP},
+		func(game *LotteryGame, count int) ([][]int, error) {
+			return game.pickSpread(count)
+		},
+	)
+)
+
+// MaxEnumeration is the largest number of possible tickets that the constrained strategy lists one by one.
+const MaxEnumeration = 500000
+
+// pickUniform picks count unique tickets by drawing distinct indices of the possible tickets one after another,
+// so every set of tickets is equally likely, no draw is wasted on a repeated ticket
+// and the tickets of a smaller count are the first of those of a larger one.
+// The uniform strategy takes no options, so the tickets are every combination of the numbers for loto types,
+// every number of each position for positional games and, as they are few, the listed tickets for numbers types.
+func (l *LotteryGame) pickUniform(count int) ([][]int, error) {
+	space := l.ResultSpace()
+	if space.Cmp(big.NewInt(int64(count))) < 0 {
+		return nil, fmt.Errorf("%w: %d requested, but only %s are possible", ErrExhausted, count, space)
+	}
+	if !space.IsInt64() {
+		return PickUnique(l, count)
+	}
+
+	var tickets [][]int
+	size := int(space.Int64())
+	if l.config.AllowDuplicate {
+		l.eachTicket(func(ticket []int) {
+			tickets = append(tickets, slices.Clone(ticket))
+		})
+		size = len(tickets)
+	}
+	results := make([][]int, 0, count)
+	for _, index := range util.Sample(l.src, size, count) {
+		switch {
+		case tickets != nil:
+			results = append(results, tickets[index])
+		case l.config.Positional():
+			results = append(results, l.positionalTicketAt(index))
+		default:
+			results = append(results, combinationAt(l.pool.items, l.config.Count, index))
+		}
+	}
+	return results, nil
+}
+
+// positionalTicketAt returns the ticket at the index of every ticket of a positional game,
+// counting the numbers of the last position first.
+func (l *LotteryGame) positionalTicketAt(index int) []int {
+	ticket := make([]int, len(l.pools))
+	for i := len(l.pools) - 1; i >= 0; i-- {
+		items := l.pools[i].items
+		ticket[i] = items[index%len(items)]
+		index /= len(items)
+	}
+	return ticket
+}
+
+// combinationAt returns the combination of k of the sorted numbers at the index of every combination
+// in lexicographic order.
+func combinationAt(numbers []int, k, index int) []int {
+	result := make([]int, 0, k)
+	for i := 0; len(result) < k; i++ {
+		// Combinations that start with numbers[i]
+		starting := int(new(big.Int).Binomial(int64(len(numbers)-i-1), int64(k-len(result)-1)).Int64())
+		if index < starting {
+			result = append(result, numbers[i])
+		} else {
+			index -= starting
+		}
+	}
+	return result
+}
+
+// pickConstrained picks count unique tickets uniformly among those that satisfy the constraints and filters.
+// If there are at most MaxEnumeration possible tickets, it lists those that pass and draws from them,
+// so rare filters never fail and the tickets are exhausted exactly (all of them are returned with ErrExhausted).
+// Otherwise it draws tickets until they pass, like PickUnique.
+func (l *LotteryGame) pickConstrained(count int) ([][]int, error) {
+	if space := l.ResultSpace(); !space.IsInt64() || space.Int64() > MaxEnumeration {
+		return PickUnique(l, count)
+	}
+
+	var tickets [][]int
+	l.eachTicket(func(ticket []int) {
+		tickets = append(tickets, slices.Clone(ticket))
+	})
+	if len(tickets) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnsatisfiable, describeFilters(l.filters))
+	}
+	if len(tickets) < count {
+		return util.Shuffle(l.src, tickets), fmt.Errorf("%w: %d requested, but only %d satisfy the filters", ErrExhausted, count, len(tickets))
+	}
+	results := make([][]int, 0, count)
+	for _, index := range util.Sample(l.src, len(tickets), count) {
+		results = append(results, tickets[index])
+	}
+	return results, nil
+}
+
+// strategies holds the available strategies by name.
+var strategies = struct {
+	mu sync.RWMutex
+	m  map[string]Strategy
+}{
+	m: map[string]Strategy{
+		UniformStrategy.Name():     UniformStrategy,
+		ConstrainedStrategy.Name(): ConstrainedStrategy,
+		WeightedStrategy.Name():    WeightedStrategy,
+		CoverageStrategy.Name():    CoverageStrategy,
+	},
+}
+
+// RegisterStrategy makes the strategy available to LookupStrategy and the --strategy flag.
+// A strategy name can only be registered once.
+func RegisterStrategy(s Strategy) error {
+	if err := validateGameName(s.Name()); err != nil {
+		return fmt.Errorf("invalid strategy name: %w", err)
+	}
+
+	strategies.mu.Lock()
+	defer strategies.mu.Unlock()
+	if _, ok := strategies.m[s.Name()]; ok {
+		return fmt.Errorf("strategy %s is already defined", s.Name())
+	}
+	strategies.m[s.Name()] = s
+	return nil
+}
+
+// LookupStrategy returns the strategy of the name.
+func LookupStrategy(name string) (Strategy, bool) {
+	strategies.mu.RLock()
+	defer strategies.mu.RUnlock()
+	s, ok := strategies.m[name]
+	return s, ok
+}
+
+// Strategies returns all available strategies sorted by name.
+func Strategies() []Strategy {
+	strategies.mu.RLock()
+	defer strategies.mu.RUnlock()
+	results := make([]Strategy, 0, len(strategies.m))
+	for _, s := range strategies.m {
+		results = append(results, s)
+	}
+	slices.SortFunc(results, func(a, b Strategy) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return results
+}
+
+// WithStrategy sets the strategy that PickN generates the tickets with.
+// By default, the strategy is chosen from the other options: coverage with an overlap limit, weighted with weights,
+// constrained with included or excluded numbers or filters, and uniform otherwise.
+func WithStrategy(s Strategy) Option {
+	return func(l *LotteryGame) {
+		l.strategy = s
+	}
+}
+
+// Strategy returns the strategy that PickN generates the tickets with.
+func (l *LotteryGame) Strategy() Strategy {
+	return l.strategy
+}
+
+// params returns the options of the game that a strategy must take.
+func (l *LotteryGame) params() []string {
+	var params []string
+	if len(l.include) > 0 {
+		params = append(params, PARAM_INCLUDE)
+	}
+	if len(l.exclude) > 0 {
+		params = append(params, PARAM_EXCLUDE)
+	}
+	if len(l.filters) > 0 {
+		params = append(params, PARAM_FILTERS)
+	}
+	if l.maxOverlap != l.config.Count {
+		params = append(params, PARAM_MAX_OVERLAP)
+	}
+	if len(l.weights) > 0 {
+		params = append(params, PARAM_WEIGHTS)
+	}
+	return params
+}
+
+// validateStrategy chooses the default strategy if none is set
+// and checks that the strategy supports the game and takes its options.
+func (l *LotteryGame) validateStrategy() error {
+	params := l.params()
+	if l.strategy == nil {
+		switch {
+		case slices.Contains(params, PARAM_MAX_OVERLAP):
+			l.strategy = CoverageStrategy
+		case slices.Contains(params, PARAM_WEIGHTS):
+			l.strategy = WeightedStrategy
+		case len(params) > 0:
+			l.strategy = ConstrainedStrategy
+		default:
+			l.strategy = UniformStrategy
+		}
+	}
+
+	if !l.strategy.Supports(l.config.Category) {
+		return fmt.Errorf("strategy %s is not available for %s games", l.strategy.Name(), l.config.Category)
+	}
+	if l.strategy == CoverageStrategy && l.config.Positional() {
+		return fmt.Errorf("strategy %s is not available for games with positions", l.strategy.Name())
+	}
+	for _, param := range params {
+		if !slices.Contains(l.strategy.Params(), param) {
+			return fmt.Errorf("strategy %s does not take %s", l.strategy.Name(), param)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package loto_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestLotteryGame_Strategy tests the strategy chosen for the options of a game
+func TestLotteryGame_Strategy(t *testing.T) {
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		opts        []loto.Option
+		want        loto.Strategy
+		wantErr     bool
+	}{
+		{name: "default", lotteryType: loto.LOTO_6, want: loto.UniformStrategy},
+		{name: "included numbers", lotteryType: loto.LOTO_6, opts: []loto.Option{loto.WithInclude(7)}, want: loto.ConstrainedStrategy},
+		{name: "filters", lotteryType: loto.NUMBERS_3, opts: []loto.Option{loto.WithFilters(loto.NoRepdigitFilter())}, want: loto.ConstrainedStrategy},
+		{name: "overlap limit", lotteryType: loto.LOTO_6, opts: []loto.Option{loto.WithExclude(4), loto.WithMaxOverlap(2)}, want: loto.CoverageStrategy},
+		{name: "spread", lotteryType: loto.LOTO_6, opts: []loto.Option{loto.WithSpread()}, want: loto.CoverageStrategy},
+		{name: "explicit", lotteryType: loto.LOTO_6, opts: []loto.Option{loto.WithStrategy(loto.ConstrainedStrategy)}, want: loto.ConstrainedStrategy},
+		{name: "option not taken", lotteryType: loto.LOTO_6, opts: []loto.Option{loto.WithStrategy(loto.UniformStrategy), loto.WithExclude(4)}, wantErr: true},
+		{name: "category not supported", lotteryType: loto.NUMBERS_4, opts: []loto.Option{loto.WithStrategy(loto.CoverageStrategy)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lottery, err := loto.NewLottery(tt.lotteryType, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewLottery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := lottery.Strategy(); got != tt.want {
+				t.Errorf("Strategy() = %v, want %v", got.Name(), tt.want.Name())
+			}
+		})
+	}
+}
+
+// TestRegisterStrategy tests that custom strategies can be registered and used
+func TestRegisterStrategy(t *testing.T) {
+	// lowest picks the lowest numbers of the range that are not excluded, then the next lowest, and so on
+	lowest := loto.NewStrategy("test-lowest", "Picks the lowest numbers",
+		[]loto.LotteryCategory{loto.LOTO},
+		[]string{loto.PARAM_EXCLUDE},
+		func(game *loto.LotteryGame, count int) ([][]int, error) {
+			config := game.Config()
+			results := make([][]int, count)
+			for i := range results {
+				for n := config.Min + i; len(results[i]) < config.Count; n++ {
+					if !slices.Contains(game.Exclude(), n) {
+						results[i] = append(results[i], n)
+					}
+				}
+			}
+			return results, nil
+		},
+	)
+	if err := loto.RegisterStrategy(lowest); err != nil {
+		t.Fatalf("RegisterStrategy() error = %v", err)
+	}
+	if err := loto.RegisterStrategy(lowest); err == nil {
+		t.Error("RegisterStrategy() of a registered name error = nil, want error")
+	}
+	if err := loto.RegisterStrategy(loto.NewStrategy("", "", nil, nil, nil)); err == nil {
+		t.Error("RegisterStrategy() of an empty name error = nil, want error")
+	}
+
+	s, ok := loto.LookupStrategy("test-lowest")
+	if !ok {
+		t.Fatal("LookupStrategy() ok = false, want true")
+	}
+	if !slices.Contains(loto.Strategies(), s) {
+		t.Error("Strategies() does not contain the registered strategy")
+	}
+
+	lottery, err := loto.NewLottery(loto.LOTO_MINI, loto.WithStrategy(s), loto.WithExclude(3))
+	if err != nil {
+		t.Fatalf("NewLottery() error = %v", err)
+	}
+	results, err := lottery.PickN(2)
+	if err != nil {
+		t.Fatalf("PickN() error = %v", err)
+	}
+	if want := [][]int{{1, 2, 4, 5, 6}, {2, 4, 5, 6, 7}}; !slices.EqualFunc(results, want, slices.Equal) {
+		t.Errorf("PickN() = %v, want %v", results, want)
+	}
+}
+
+// TestStrategies tests that the built-in strategies are available in name order
+func TestStrategies(t *testing.T) {
+	var names []string
+	for _, s := range loto.Strategies() {
+		names = append(names, s.Name())
+	}
+	for _, name := range []string{"constrained", "coverage", "uniform"} {
+		if !slices.Contains(names, name) {
+			t.Errorf("Strategies() = %v, want it to contain %s", names, name)
+		}
+	}
+	if !slices.IsSorted(names) {
+		t.Errorf("Strategies() = %v, want it sorted", names)
+	}
+}
+
+// TestLotteryGame_PickN_InvalidStrategy tests that the tickets of a custom strategy are checked against the game
+func TestLotteryGame_PickN_InvalidStrategy(t *testing.T) {
+	tests := []struct {
+		name    string
+		tickets [][]int
+	}{
+		{"out of range", [][]int{{1, 2, 3, 4, 32}}},
+		{"too few numbers", [][]int{{1, 2, 3, 4}}},
+		{"excluded number", [][]int{{1, 2, 3, 4, 9}}},
+		{"included number missing", [][]int{{1, 2, 3, 4, 5}}},
+		{"repeated ticket", [][]int{{1, 2, 3, 7, 8}, {1, 2, 3, 7, 8}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixed := loto.NewStrategy("test-fixed", "Picks fixed tickets",
+				[]loto.LotteryCategory{loto.LOTO},
+				[]string{loto.PARAM_INCLUDE, loto.PARAM_EXCLUDE},
+				func(*loto.LotteryGame, int) ([][]int, error) {
+					return tt.tickets, nil
+				},
+			)
+			lottery, err := loto.NewLottery(loto.LOTO_MINI, loto.WithStrategy(fixed), loto.WithInclude(7), loto.WithExclude(9))
+			if err != nil {
+				t.Fatalf("NewLottery() error = %v", err)
+			}
+			if _, err := lottery.PickN(len(tt.tickets)); err == nil {
+				t.Error("PickN() error = nil, want error")
+			}
+		})
+	}
+}
+
+// TestBuiltinStrategies tests that the uniform strategy can pick every ticket
+// and that the constrained strategy finds the rare tickets that pass its filters
+func TestBuiltinStrategies(t *testing.T) {
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		opts        []loto.Option
+		count       int
+		want        int // number of tickets picked
+	}{
+		{"uniform loto", loto.LOTO_6, nil, 10, 10},
+		{"uniform box", loto.NUMBERS_3, []loto.Option{loto.WithBet(loto.BOX)}, 210, 210},
+		{"uniform bingo", loto.BINGO_5, nil, 10, 10},
+		{"constrained rare filter", loto.NUMBERS_3, []loto.Option{loto.WithFilters(loto.SumFilter(loto.Range{Min: 26, Max: 27})), loto.WithCap()}, 10, 4},
+		{"constrained loto", loto.LOTO_MINI, []loto.Option{loto.WithInclude(1, 2, 3), loto.WithExclude(4)}, 5, 5},
+		{"constrained bingo", loto.BINGO_5, []loto.Option{loto.WithInclude(1, 6, 11, 16, 21, 26, 31)}, 5, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := loto.Lookup(tt.lotteryType)
+			lottery, err := loto.NewLottery(tt.lotteryType, append(tt.opts, loto.WithSeed(1))...)
+			if err != nil {
+				t.Fatalf("NewLottery() error = %v", err)
+			}
+			results, err := lottery.PickN(tt.count)
+			if err != nil {
+				t.Fatalf("PickN() error = %v", err)
+			}
+			if len(results) != tt.want {
+				t.Fatalf("PickN() returned %d tickets, want %d", len(results), tt.want)
+			}
+			for _, result := range results {
+				if !config.AllowDuplicate {
+					if err := config.ValidateNumbers(result); err != nil {
+						t.Errorf("PickN() result %v is invalid: %v", result, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestBuiltinStrategies_Prefix tests that the uniform and constrained strategies pick the first tickets
+// of a larger count when they pick fewer tickets with the same seed
+func TestBuiltinStrategies_Prefix(t *testing.T) {
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		opts        []loto.Option
+	}{
+		{"uniform loto", loto.LOTO_6, nil},
+		{"uniform numbers", loto.NUMBERS_4, nil},
+		{"constrained loto", loto.LOTO_MINI, []loto.Option{loto.WithInclude(7)}},
+		{"uniform bingo", loto.BINGO_5, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var previous [][]int
+			for count := 1; count <= 5; count++ {
+				lottery, err := loto.NewLottery(tt.lotteryType, append(tt.opts, loto.WithSeed(3))...)
+				if err != nil {
+					t.Fatalf("NewLottery() error = %v", err)
+				}
+				results, err := lottery.PickN(count)
+				if err != nil {
+					t.Fatalf("PickN(%d) error = %v", count, err)
+				}
+				if !slices.EqualFunc(results[:len(previous)], previous, slices.Equal) {
+					t.Errorf("PickN(%d) = %v, want it to start with %v", count, results, previous)
+				}
+				previous = results
+			}
+		})
+	}
+}
@@ -2,11 +2,10 @@ package loto
 
 import (
 	"fmt"
-	"slices"
 	"strings"
 )
 
-// LotteryCategory represents the category of lottery (LOTO or NUMBERS).
+// LotteryCategory represents the category of lottery (LOTO, NUMBERS, BINGO or JUMBO).
 type LotteryCategory string
 
 // LotteryType represents a specific lottery type.
@@ -16,24 +15,24 @@ const (
 	// Lottery categories
 	LOTO    = LotteryCategory("loto")
 	NUMBERS = LotteryCategory("numbers")
+	BINGO   = LotteryCategory("bingo")
+	JUMBO   = LotteryCategory("jumbo")
 
 	// Lottery types
-	LOTO_6    = LotteryType("loto6")
-	LOTO_7    = LotteryType("loto7")
-	LOTO_MINI = LotteryType("miniloto")
-	NUMBERS_3 = LotteryType("numbers3")
-	NUMBERS_4 = LotteryType("numbers4")
+	LOTO_6           = LotteryType("loto6")
+	LOTO_7           = LotteryType("loto7")
+	LOTO_MINI        = LotteryType("miniloto")
+	NUMBERS_3        = LotteryType("numbers3")
+	NUMBERS_4        = LotteryType("numbers4")
+	BINGO_5          = LotteryType("bingo5")
+	JUMBO_TAKARAKUJI = LotteryType("jumbo")
 )
 
 // Validate checks if the lottery type is valid.
 func (c LotteryType) Validate() error {
-	if _, ok := LotteryConfigs[c]; !ok {
-		// Build list of valid types from config
-		validTypes := make([]string, 0, len(LotteryConfigs))
-		for lotteryType := range LotteryConfigs {
-			validTypes = append(validTypes, string(lotteryType))
-		}
-		slices.Sort(validTypes)
+	if _, ok := Lookup(c); !ok {
+		// Build list of valid types from the registry
+		validTypes := Names()
 		return fmt.Errorf("invalid lottery type: %s. It must be one of %s", c, strings.Join(validTypes, ", "))
 	}
 	return nil
@@ -44,9 +43,9 @@ func (t LotteryType) String() string {
 	return string(t)
 }
 
-// GetCategory returns the category of lottery (LOTO or NUMBERS) based on the given LotteryType.
+// GetCategory returns the category of lottery (LOTO, NUMBERS, BINGO or JUMBO) based on the given LotteryType.
 func GetCategory(t LotteryType) LotteryCategory {
-	if config, ok := LotteryConfigs[t]; ok {
+	if config, ok := Lookup(t); ok {
 		return config.Category
 	}
 	return ""
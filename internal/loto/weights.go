@@ -0,0 +1,181 @@
+package loto
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Weights holds the weight of each number when picking tickets.
+// Numbers without a weight have a weight of 1, so a weight of 3 makes a number three times as likely.
+type Weights map[int]float64
+
+// Weight returns the weight of the number.
+func (w Weights) Weight(n int) float64 {
+	if weight, ok := w[n]; ok {
+		return weight
+	}
+	return 1
+}
+
+// Validate checks that every weight is a positive number for a number of the lottery.
+func (w Weights) Validate(config LotteryConfig) error {
+	for n, weight := range w {
+		if n < config.Min || n > config.Max {
+			return fmt.Errorf("weight of number out of range: %d. It must be between %d and %d", n, config.Min, config.Max)
+		}
+		if weight <= 0 || math.IsInf(weight, 0) || math.IsNaN(weight) {
+			return fmt.Errorf("weight of %d must be a positive number, got %v. Exclude the number to never pick it", n, weight)
+		}
+	}
+	return nil
+}
+
+// Multiply returns the weights of w multiplied by the weights of other.
+func (w Weights) Multiply(other Weights) Weights {
+	results := make(Weights, len(w)+len(other))
+	for n := range w {
+		results[n] = w.Weight(n) * other.Weight(n)
+	}
+	for n := range other {
+		results[n] = w.Weight(n) * other.Weight(n)
+	}
+	return results
+}
+
+// weightsFile is the layout of a weights file.
+type weightsFile struct {
+	Weights map[string]float64 `json:"weights" yaml:"weights" toml:"weights"`
+}
+
+// ParseWeights parses a weights file.
+// The format is chosen by the file extension: .yaml/.yml, .toml or .json.
+//
+//	weights:
+//	  7: 3
+//	  13: 3
+func ParseWeights(path string, data []byte) (Weights, error) {
+	var file weightsFile
+	var err error
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &file)
+	case ".toml":
+		err = toml.Unmarshal(data, &file)
+	case ".json":
+		err = json.Unmarshal(data, &file)
+	default:
+		return nil, fmt.Errorf("unsupported weights file format: %s", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	weights := make(Weights, len(file.Weights))
+	for key, weight := range file.Weights {
+		n, err := strconv.Atoi(strings.TrimSpace(key))
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid number: %q", path, key)
+		}
+		weights[n] = weight
+	}
+	return weights, nil
+}
+
+// LoadWeights reads a weights file.
+func LoadWeights(path string) (Weights, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWeights(path, data)
+}
+
+// Bias selects how past draws weight the numbers.
+type Bias string
+
+const (
+	// Biases of the weights derived from past draws
+	HOT  = Bias("hot")  // Numbers drawn more often are more likely
+	COLD = Bias("cold") // Numbers drawn less often are more likely
+)
+
+// Biases returns all biases.
+func Biases() []Bias {
+	return []Bias{HOT, COLD}
+}
+
+// Validate checks if the bias is valid.
+func (b Bias) Validate() error {
+	if !slices.Contains(Biases(), b) {
+		return fmt.Errorf("invalid bias: %s. It must be hot or cold", b)
+	}
+	return nil
+}
+
+// FrequencyWeights derives weights from the main numbers of past draws.
+// A number drawn k times weighs k+1 with the hot bias, and m-k+1 with the cold bias,
+// where m is the count of the most drawn number. Every number keeps a positive weight.
+func FrequencyWeights(config LotteryConfig, draws [][]int, bias Bias) (Weights, error) {
+	if err := bias.Validate(); err != nil {
+		return nil, err
+	}
+	if len(draws) == 0 {
+		return nil, fmt.Errorf("no past draws to derive weights from")
+	}
+
+	counts := make(map[int]int)
+	most := 0
+	for _, draw := range draws {
+		for _, n := range draw {
+			counts[n]++
+			most = max(most, counts[n])
+		}
+	}
+
+	weights := make(Weights, config.Max-config.Min+1)
+	for n := config.Min; n <= config.Max; n++ {
+		if bias == HOT {
+			weights[n] = float64(counts[n] + 1)
+		} else {
+			weights[n] = float64(most - counts[n] + 1)
+		}
+	}
+	return weights, nil
+}
+
+// ProbabilitySamples is the number of tickets picked to estimate the probabilities of the numbers.
+const ProbabilitySamples = 100000
+
+// Probabilities estimates the probability that a picked ticket contains each number of the lottery
+// by picking samples tickets, so the weights, the constraints and the filters are all taken into account.
+// It uses the random source of the game.
+func (l *LotteryGame) Probabilities(samples int) (map[int]float64, error) {
+	counts := make(map[int]int)
+	for range samples {
+		ticket, err := l.Pick()
+		if err != nil {
+			return nil, err
+		}
+		for i, n := range ticket {
+			// Count repeated digits once
+			if !slices.Contains(ticket[:i], n) {
+				counts[n]++
+			}
+		}
+	}
+
+	probabilities := make(map[int]float64, l.config.Max-l.config.Min+1)
+	for n := l.config.Min; n <= l.config.Max; n++ {
+		probabilities[n] = float64(counts[n]) / float64(samples)
+	}
+	return probabilities, nil
+}
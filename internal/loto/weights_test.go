@@ -0,0 +1,188 @@
+package loto_test
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/kawana77b/loto/internal/util"
+)
+
+// TestBox_Weights tests that weighted boxes pick their items in proportion to the weights
+func TestBox_Weights(t *testing.T) {
+	const trials = 100000
+	weights := map[int]float64{1: 3, 2: 1, 3: 1, 4: 1, 5: 2}
+
+	t.Run("PickDupN", func(t *testing.T) {
+		box := loto.NewBox(1, 5, loto.WithBoxSource(util.NewSeededSource(1)), loto.WithBoxWeights(weights))
+		counts := make(map[int]int)
+		for _, n := range box.PickDupN(trials) {
+			counts[n]++
+		}
+		for n, w := range weights {
+			want := w / 8
+			if got := float64(counts[n]) / trials; math.Abs(got-want) > 0.01 {
+				t.Errorf("PickDupN() frequency of %d = %.3f, want %.3f", n, got, want)
+			}
+		}
+	})
+
+	t.Run("PickN", func(t *testing.T) {
+		box := loto.NewBox(1, 5, loto.WithBoxSource(util.NewSeededSource(1)), loto.WithBoxWeights(weights))
+		first := make(map[int]int)
+		for range trials {
+			picked := box.PickN(2)
+			if len(picked) != 2 || picked[0] == picked[1] {
+				t.Fatalf("PickN() = %v, want 2 unique items", picked)
+			}
+			first[picked[0]]++
+		}
+		// The first item is drawn from every item in proportion to the weights
+		for n, w := range weights {
+			want := w / 8
+			if got := float64(first[n]) / trials; math.Abs(got-want) > 0.01 {
+				t.Errorf("PickN() frequency of %d as the first item = %.3f, want %.3f", n, got, want)
+			}
+		}
+	})
+}
+
+// TestParseWeights tests parsing the weights file formats
+func TestParseWeights(t *testing.T) {
+	files := map[string]string{
+		"weights.yaml": "weights:\n  7: 3\n  13: 2.5\n",
+		"weights.toml": "[weights]\n7 = 3\n13 = 2.5\n",
+		"weights.json": `{"weights": {"7": 3, "13": 2.5}}`,
+	}
+
+	for file, content := range files {
+		t.Run(file, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), file)
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			weights, err := loto.LoadWeights(path)
+			if err != nil {
+				t.Fatalf("LoadWeights() error = %v", err)
+			}
+			if weights.Weight(7) != 3 || weights.Weight(13) != 2.5 || weights.Weight(1) != 1 {
+				t.Errorf("LoadWeights() = %v, want 7: 3, 13: 2.5", weights)
+			}
+		})
+	}
+
+	t.Run("invalid number", func(t *testing.T) {
+		if _, err := loto.ParseWeights("weights.yaml", []byte("weights:\n  seven: 3\n")); err == nil {
+			t.Error("ParseWeights() error = nil, want error")
+		}
+	})
+}
+
+// TestWeights_Validate tests the Validate method of Weights
+func TestWeights_Validate(t *testing.T) {
+	config, _ := loto.Lookup(loto.LOTO_6)
+
+	tests := []struct {
+		name    string
+		weights loto.Weights
+		wantErr bool
+	}{
+		{name: "valid", weights: loto.Weights{7: 3, 13: 0.5}},
+		{name: "out of range", weights: loto.Weights{44: 3}, wantErr: true},
+		{name: "zero", weights: loto.Weights{7: 0}, wantErr: true},
+		{name: "negative", weights: loto.Weights{7: -1}, wantErr: true},
+		{name: "infinite", weights: loto.Weights{7: math.Inf(1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.weights.Validate(config); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestFrequencyWeights tests the weights derived from past draws
+func TestFrequencyWeights(t *testing.T) {
+	config, _ := loto.Lookup(loto.LOTO_MINI)
+	draws := [][]int{
+		{1, 2, 3, 4, 5},
+		{1, 2, 3, 6, 7},
+		{1, 8, 9, 10, 11},
+	}
+
+	hot, err := loto.FrequencyWeights(config, draws, loto.HOT)
+	if err != nil {
+		t.Fatalf("FrequencyWeights() error = %v", err)
+	}
+	cold, err := loto.FrequencyWeights(config, draws, loto.COLD)
+	if err != nil {
+		t.Fatalf("FrequencyWeights() error = %v", err)
+	}
+
+	tests := []struct {
+		n        int
+		wantHot  float64
+		wantCold float64
+	}{
+		{n: 1, wantHot: 4, wantCold: 1},
+		{n: 2, wantHot: 3, wantCold: 2},
+		{n: 4, wantHot: 2, wantCold: 3},
+		{n: 31, wantHot: 1, wantCold: 4},
+	}
+	for _, tt := range tests {
+		if got := hot.Weight(tt.n); got != tt.wantHot {
+			t.Errorf("hot weight of %d = %v, want %v", tt.n, got, tt.wantHot)
+		}
+		if got := cold.Weight(tt.n); got != tt.wantCold {
+			t.Errorf("cold weight of %d = %v, want %v", tt.n, got, tt.wantCold)
+		}
+	}
+
+	if _, err := loto.FrequencyWeights(config, nil, loto.HOT); err == nil {
+		t.Error("FrequencyWeights() without draws error = nil, want error")
+	}
+	if _, err := loto.FrequencyWeights(config, draws, loto.Bias("warm")); err == nil {
+		t.Error("FrequencyWeights() with an invalid bias error = nil, want error")
+	}
+}
+
+// TestWeights_Multiply tests the Multiply method of Weights
+func TestWeights_Multiply(t *testing.T) {
+	got := loto.Weights{1: 2, 2: 3}.Multiply(loto.Weights{2: 2, 3: 4})
+	want := loto.Weights{1: 2, 2: 6, 3: 4}
+	if len(got) != len(want) {
+		t.Fatalf("Multiply() = %v, want %v", got, want)
+	}
+	for n, w := range want {
+		if got[n] != w {
+			t.Errorf("Multiply() = %v, want %v", got, want)
+		}
+	}
+}
+
+// TestLotteryGame_Weights tests the probabilities of a weighted game
+func TestLotteryGame_Weights(t *testing.T) {
+	lottery, err := loto.NewLottery(loto.NUMBERS_3, loto.WithSeed(1), loto.WithWeights(loto.Weights{7: 11}))
+	if err != nil {
+		t.Fatalf("NewLottery() error = %v", err)
+	}
+	if lottery.Strategy() != loto.WeightedStrategy {
+		t.Errorf("Strategy() = %v, want weighted", lottery.Strategy().Name())
+	}
+
+	probabilities, err := lottery.Probabilities(20000)
+	if err != nil {
+		t.Fatalf("Probabilities() error = %v", err)
+	}
+	// Each digit is 7 with a probability of 11/20, so a ticket contains 7 with 1 - (9/20)^3
+	if got, want := probabilities[7], 1-math.Pow(9.0/20, 3); math.Abs(got-want) > 0.02 {
+		t.Errorf("Probabilities() of 7 = %.3f, want %.3f", got, want)
+	}
+	if got, want := probabilities[0], 1-math.Pow(19.0/20, 3); math.Abs(got-want) > 0.02 {
+		t.Errorf("Probabilities() of 0 = %.3f, want %.3f", got, want)
+	}
+}
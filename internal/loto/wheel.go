@@ -0,0 +1,158 @@
+package loto
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"slices"
+)
+
+const (
+	// MaxWheelPool is the largest pool a wheel can be made of.
+	MaxWheelPool = 64
+	// MaxWheelLines is the largest number of lines a full wheel, or the candidates of an abbreviated wheel, can have.
+	MaxWheelLines = 100000
+	// maxWheelWork bounds the line and guarantee pairs checked when abbreviating a wheel.
+	maxWheelWork = 20000000
+)
+
+// WheelGuarantee is the guarantee of an abbreviated wheel:
+// if Drawn of the drawn main numbers are in the pool, at least one line has Match of them.
+type WheelGuarantee struct {
+	Drawn int
+	Match int
+}
+
+// String describes the guarantee.
+func (g WheelGuarantee) String() string {
+	return fmt.Sprintf("if %d of the drawn numbers are in the pool, at least one line has %d", g.Drawn, g.Match)
+}
+
+// Wheel is a set of lines played from a pool of numbers.
+type Wheel struct {
+	Type      LotteryType
+	Pool      []int           // Numbers of the pool in ascending order
+	Lines     [][]int         // Lines in ascending order of their numbers
+	Guarantee *WheelGuarantee // Guarantee of an abbreviated wheel (nil for a full wheel)
+	config    LotteryConfig
+}
+
+// NewWheel creates a wheel of the loto type from the pool.
+// Without a guarantee, it is a full wheel: every combination of the pool, which wins the jackpot
+// if all the drawn main numbers are in the pool.
+// With a guarantee, it is an abbreviated wheel: lines are chosen greedily, each covering the most
+// combinations of Drawn numbers not covered yet, until the guarantee holds for every combination.
+// The result is deterministic, but it is not always the smallest possible wheel.
+func NewWheel(t LotteryType, pool []int, guarantee *WheelGuarantee) (*Wheel, error) {
+	config, ok := Lookup(t)
+	if !ok {
+		return nil, fmt.Errorf("invalid lottery type: %s", t)
+	}
+	if config.Category != LOTO || config.Positional() {
+		return nil, fmt.Errorf("wheels are only available for loto games without positions")
+	}
+	if err := config.validateRange(pool); err != nil {
+		return nil, fmt.Errorf("invalid pool: %w", err)
+	}
+	if len(pool) < config.Count || len(pool) > MaxWheelPool {
+		return nil, fmt.Errorf("invalid pool: it must have between %d and %d numbers, got %d", config.Count, MaxWheelPool, len(pool))
+	}
+	lines := new(big.Int).Binomial(int64(len(pool)), int64(config.Count))
+	if lines.Cmp(big.NewInt(MaxWheelLines)) > 0 {
+		return nil, fmt.Errorf("pool too large: %d numbers make %s lines, but at most %d are supported", len(pool), lines, MaxWheelLines)
+	}
+
+	w := &Wheel{
+		Type:      t,
+		Pool:      slices.Sorted(slices.Values(pool)),
+		Guarantee: guarantee,
+		config:    config,
+	}
+	candidates := combinationMasks(len(pool), config.Count)
+	if guarantee != nil {
+		if err := w.validateGuarantee(); err != nil {
+			return nil, err
+		}
+		targets := combinationMasks(len(pool), guarantee.Drawn)
+		if len(candidates)*len(targets) > maxWheelWork {
+			return nil, fmt.Errorf("pool too large for an abbreviated wheel: %d numbers", len(pool))
+		}
+		candidates = coverGreedily(candidates, targets, guarantee.Match)
+	}
+	for _, mask := range candidates {
+		w.Lines = append(w.Lines, w.numbers(mask))
+	}
+	slices.SortFunc(w.Lines, slices.Compare)
+	return w, nil
+}
+
+// validateGuarantee checks that the guarantee can be given by a wheel of the pool.
+func (w *Wheel) validateGuarantee() error {
+	g := w.Guarantee
+	if g.Drawn < 1 || g.Drawn > w.config.Count || g.Drawn > len(w.Pool) {
+		return fmt.Errorf("invalid guarantee: drawn numbers must be between 1 and %d, got %d", min(w.config.Count, len(w.Pool)), g.Drawn)
+	}
+	if g.Match < 1 || g.Match > g.Drawn {
+		return fmt.Errorf("invalid guarantee: matches must be between 1 and %d, got %d", g.Drawn, g.Match)
+	}
+	return nil
+}
+
+// Cost returns the price of all the lines in yen (0 if the price of the game is unknown).
+func (w *Wheel) Cost() int {
+	return len(w.Lines) * w.config.Price
+}
+
+// numbers returns the numbers of the pool selected by the mask.
+func (w *Wheel) numbers(mask uint64) []int {
+	numbers := make([]int, 0, bits.OnesCount64(mask))
+	for i, n := range w.Pool {
+		if mask&(1<<i) != 0 {
+			numbers = append(numbers, n)
+		}
+	}
+	return numbers
+}
+
+// combinationMasks returns every combination of k of n indexes as bit masks, in lexicographic order.
+func combinationMasks(n, k int) []uint64 {
+	var masks []uint64
+	var walk func(from int, mask uint64, left int)
+	walk = func(from int, mask uint64, left int) {
+		if left == 0 {
+			masks = append(masks, mask)
+			return
+		}
+		for i := from; i <= n-left; i++ {
+			walk(i+1, mask|1<<i, left-1)
+		}
+	}
+	walk(0, 0, k)
+	return masks
+}
+
+// coverGreedily chooses candidates until every target shares at least match indexes with one of them.
+// Each step chooses the first candidate that covers the most targets not covered yet.
+func coverGreedily(candidates, targets []uint64, match int) []uint64 {
+	var chosen []uint64
+	uncovered := slices.Clone(targets)
+	for len(uncovered) > 0 {
+		best, bestCount := 0, 0
+		for i, candidate := range candidates {
+			count := 0
+			for _, target := range uncovered {
+				if bits.OnesCount64(candidate&target) >= match {
+					count++
+				}
+			}
+			if count > bestCount {
+				best, bestCount = i, count
+			}
+		}
+		chosen = append(chosen, candidates[best])
+		uncovered = slices.DeleteFunc(uncovered, func(target uint64) bool {
+			return bits.OnesCount64(candidates[best]&target) >= match
+		})
+	}
+	return chosen
+}
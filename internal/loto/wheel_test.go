@@ -0,0 +1,150 @@
+package loto_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// TestNewWheel_Full tests that a full wheel has every combination of the pool
+func TestNewWheel_Full(t *testing.T) {
+	pool := []int{41, 3, 7, 12, 18, 22, 29, 33}
+	wheel, err := loto.NewWheel(loto.LOTO_6, pool, nil)
+	if err != nil {
+		t.Fatalf("NewWheel() error = %v", err)
+	}
+
+	if len(wheel.Lines) != 28 {
+		t.Errorf("NewWheel() lines = %v, want 28", len(wheel.Lines))
+	}
+	if got := wheel.Cost(); got != 5600 {
+		t.Errorf("Cost() = %v, want 5600", got)
+	}
+	if want := []int{3, 7, 12, 18, 22, 29}; !slices.Equal(wheel.Lines[0], want) {
+		t.Errorf("NewWheel() first line = %v, want %v", wheel.Lines[0], want)
+	}
+	for i := 1; i < len(wheel.Lines); i++ {
+		if slices.Compare(wheel.Lines[i-1], wheel.Lines[i]) >= 0 {
+			t.Errorf("NewWheel() lines not sorted or duplicated: %v, %v", wheel.Lines[i-1], wheel.Lines[i])
+		}
+	}
+}
+
+// TestNewWheel_Abbreviated tests that an abbreviated wheel keeps its guarantee for every possible draw
+func TestNewWheel_Abbreviated(t *testing.T) {
+	tests := []struct {
+		name      string
+		lottery   loto.LotteryType
+		pool      []int
+		guarantee loto.WheelGuarantee
+		maxLines  int
+	}{
+		{
+			name:      "loto6 8 numbers 3 if 4",
+			lottery:   loto.LOTO_6,
+			pool:      []int{3, 7, 12, 18, 22, 29, 33, 41},
+			guarantee: loto.WheelGuarantee{Drawn: 4, Match: 3},
+			maxLines:  28,
+		},
+		{
+			name:      "loto6 10 numbers 4 if 5",
+			lottery:   loto.LOTO_6,
+			pool:      []int{1, 5, 9, 13, 17, 21, 25, 29, 33, 37},
+			guarantee: loto.WheelGuarantee{Drawn: 5, Match: 4},
+			maxLines:  210,
+		},
+		{
+			name:      "miniloto 9 numbers 3 if 3",
+			lottery:   loto.LOTO_MINI,
+			pool:      []int{2, 4, 6, 8, 10, 12, 14, 16, 18},
+			guarantee: loto.WheelGuarantee{Drawn: 3, Match: 3},
+			maxLines:  126,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wheel, err := loto.NewWheel(tt.lottery, tt.pool, &tt.guarantee)
+			if err != nil {
+				t.Fatalf("NewWheel() error = %v", err)
+			}
+			if len(wheel.Lines) == 0 || len(wheel.Lines) >= tt.maxLines {
+				t.Errorf("NewWheel() lines = %v, want fewer than the full wheel (%d)", len(wheel.Lines), tt.maxLines)
+			}
+
+			// Every combination of drawn numbers in the pool must be matched by a line
+			for _, drawn := range combinations(tt.pool, tt.guarantee.Drawn) {
+				if !slices.ContainsFunc(wheel.Lines, func(line []int) bool {
+					return countMatches(line, drawn) >= tt.guarantee.Match
+				}) {
+					t.Fatalf("NewWheel() no line has %d of %v", tt.guarantee.Match, drawn)
+				}
+			}
+		})
+	}
+}
+
+// TestNewWheel_Invalid tests that invalid wheels are rejected
+func TestNewWheel_Invalid(t *testing.T) {
+	positional := loto.LotteryConfig{
+		Category:  loto.LOTO,
+		Count:     3,
+		Min:       1,
+		Max:       30,
+		Positions: []loto.Range{{Min: 1, Max: 10}, {Min: 11, Max: 20}, {Min: 21, Max: 30}},
+	}
+	if err := loto.Register("wheel-positional3", positional); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		lottery   loto.LotteryType
+		pool      []int
+		guarantee *loto.WheelGuarantee
+	}{
+		{name: "numbers game", lottery: loto.NUMBERS_3, pool: []int{1, 2, 3}},
+		{name: "positional loto game", lottery: "wheel-positional3", pool: []int{1, 2, 11, 12, 21, 22}},
+		{name: "pool too small", lottery: loto.LOTO_6, pool: []int{1, 2, 3, 4, 5}},
+		{name: "duplicate in pool", lottery: loto.LOTO_6, pool: []int{1, 2, 3, 4, 5, 5, 6}},
+		{name: "out of range", lottery: loto.LOTO_6, pool: []int{1, 2, 3, 4, 5, 44}},
+		{name: "pool too large", lottery: loto.LOTO_6, pool: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30}},
+		{name: "more drawn than a draw has", lottery: loto.LOTO_6, pool: []int{1, 2, 3, 4, 5, 6, 7, 8}, guarantee: &loto.WheelGuarantee{Drawn: 7, Match: 3}},
+		{name: "more matches than drawn", lottery: loto.LOTO_6, pool: []int{1, 2, 3, 4, 5, 6, 7, 8}, guarantee: &loto.WheelGuarantee{Drawn: 3, Match: 4}},
+		{name: "no matches", lottery: loto.LOTO_6, pool: []int{1, 2, 3, 4, 5, 6, 7, 8}, guarantee: &loto.WheelGuarantee{Drawn: 3, Match: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := loto.NewWheel(tt.lottery, tt.pool, tt.guarantee); err == nil {
+				t.Error("NewWheel() error = nil, want error")
+			}
+		})
+	}
+}
+
+// combinations returns every combination of k elements of s.
+func combinations(s []int, k int) [][]int {
+	if k == 0 {
+		return [][]int{{}}
+	}
+	var results [][]int
+	for i := 0; i <= len(s)-k; i++ {
+		for _, rest := range combinations(s[i+1:], k-1) {
+			results = append(results, append([]int{s[i]}, rest...))
+		}
+	}
+	return results
+}
+
+// countMatches returns the number of elements of a that are in b.
+func countMatches(a, b []int) int {
+	count := 0
+	for _, n := range a {
+		if slices.Contains(b, n) {
+			count++
+		}
+	}
+	return count
+}
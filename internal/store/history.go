@@ -0,0 +1,130 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/kawana77b/loto/internal/loto"
+)
+
+// ErrNotFound is returned when a history entry does not exist.
+var ErrNotFound = errors.New("not found")
+
+// HistoryEntry records a generated ticket set.
+type HistoryEntry struct {
+	ID        int                `json:"id"`                // Sequential ID of the entry
+	Type      loto.LotteryType   `json:"type"`              // Lottery type of the tickets
+	CreatedAt time.Time          `json:"created_at"`        // Time the tickets were generated
+	Seed      *uint64            `json:"seed,omitempty"`    // Seed of the random source (nil if not seeded)
+	Bet       loto.BetType       `json:"bet,omitempty"`     // Bet type of numbers tickets
+	Draw      int                `json:"draw,omitempty"`    // Draw number (回号) the tickets are for (0 if unknown)
+	Tickets   [][]int            `json:"tickets,omitempty"` // Generated tickets (empty for jumbo types)
+	Jumbo     []loto.JumboTicket `json:"jumbo,omitempty"`   // Generated tickets of jumbo types
+}
+
+// HistoryFilter selects history entries. Zero fields match every entry.
+type HistoryFilter struct {
+	Type  loto.LotteryType // Lottery type
+	Since Date             // First date (inclusive, local time)
+	Until Date             // Last date (inclusive, local time)
+}
+
+// Match reports whether the entry is selected by the filter.
+func (f HistoryFilter) Match(entry HistoryEntry) bool {
+	if f.Type != "" && entry.Type != f.Type {
+		return false
+	}
+	date := NewDate(entry.CreatedAt.Local())
+	if !f.Since.IsZero() && date.Before(f.Since.Time) {
+		return false
+	}
+	if !f.Until.IsZero() && date.After(f.Until.Time) {
+		return false
+	}
+	return true
+}
+
+// HistoryStore stores generated ticket sets in a JSON Lines file.
+type HistoryStore struct {
+	path string
+}
+
+// NewHistoryStore creates a history store backed by the given file.
+func NewHistoryStore(path string) *HistoryStore {
+	return &HistoryStore{
+		path: path,
+	}
+}
+
+// DefaultHistoryStore returns the history store under the data directory.
+func DefaultHistoryStore() (*HistoryStore, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewHistoryStore(filepath.Join(dir, "history.jsonl")), nil
+}
+
+// Add records a new entry and returns it with its assigned ID.
+func (s *HistoryStore) Add(entry HistoryEntry) (HistoryEntry, error) {
+	entries, err := readJSONL[HistoryEntry](s.path)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+
+	entry.ID = 1
+	for _, e := range entries {
+		entry.ID = max(entry.ID, e.ID+1)
+	}
+	if err := writeJSONL(s.path, append(entries, entry)); err != nil {
+		return HistoryEntry{}, err
+	}
+	return entry, nil
+}
+
+// List returns the entries selected by the filter, oldest first.
+func (s *HistoryStore) List(filter HistoryFilter) ([]HistoryEntry, error) {
+	entries, err := readJSONL[HistoryEntry](s.path)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(entries, func(e HistoryEntry) bool {
+		return !filter.Match(e)
+	}), nil
+}
+
+// Get returns the entry with the given ID.
+func (s *HistoryStore) Get(id int) (HistoryEntry, error) {
+	entries, err := readJSONL[HistoryEntry](s.path)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	i := slices.IndexFunc(entries, func(e HistoryEntry) bool {
+		return e.ID == id
+	})
+	if i < 0 {
+		return HistoryEntry{}, fmt.Errorf("history entry %d: %w", id, ErrNotFound)
+	}
+	return entries[i], nil
+}
+
+// Delete removes the entries with the given IDs.
+// Nothing is removed if any of the IDs does not exist.
+func (s *HistoryStore) Delete(ids ...int) error {
+	entries, err := readJSONL[HistoryEntry](s.path)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if !slices.ContainsFunc(entries, func(e HistoryEntry) bool { return e.ID == id }) {
+			return fmt.Errorf("history entry %d: %w", id, ErrNotFound)
+		}
+	}
+	entries = slices.DeleteFunc(entries, func(e HistoryEntry) bool {
+		return slices.Contains(ids, e.ID)
+	})
+	return writeJSONL(s.path, entries)
+}
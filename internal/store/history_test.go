@@ -0,0 +1,93 @@
+package store_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/kawana77b/loto/internal/store"
+)
+
+// TestHistoryStore tests adding, listing, getting and deleting history entries
+func TestHistoryStore(t *testing.T) {
+	history := store.NewHistoryStore(filepath.Join(t.TempDir(), "history.jsonl"))
+
+	seed := uint64(42)
+	entries := []store.HistoryEntry{
+		{Type: loto.LOTO_6, CreatedAt: time.Date(2024, 1, 4, 12, 0, 0, 0, time.Local), Seed: &seed, Tickets: [][]int{{1, 2, 3, 4, 5, 6}}},
+		{Type: loto.NUMBERS_3, CreatedAt: time.Date(2024, 1, 5, 12, 0, 0, 0, time.Local), Bet: loto.BOX, Tickets: [][]int{{1, 2, 3}}},
+		{Type: loto.LOTO_6, CreatedAt: time.Date(2024, 1, 8, 12, 0, 0, 0, time.Local), Tickets: [][]int{{7, 8, 9, 10, 11, 12}}},
+	}
+	for i, entry := range entries {
+		added, err := history.Add(entry)
+		if err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		if added.ID != i+1 {
+			t.Errorf("Add() ID = %v, want %v", added.ID, i+1)
+		}
+	}
+
+	t.Run("filter", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			filter  store.HistoryFilter
+			wantIDs []int
+		}{
+			{"all", store.HistoryFilter{}, []int{1, 2, 3}},
+			{"by type", store.HistoryFilter{Type: loto.LOTO_6}, []int{1, 3}},
+			{"since", store.HistoryFilter{Since: mustDate(t, "2024-01-05")}, []int{2, 3}},
+			{"until", store.HistoryFilter{Until: mustDate(t, "2024-01-05")}, []int{1, 2}},
+			{"type and dates", store.HistoryFilter{Type: loto.LOTO_6, Since: mustDate(t, "2024-01-05"), Until: mustDate(t, "2024-01-31")}, []int{3}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := history.List(tt.filter)
+				if err != nil {
+					t.Fatalf("List() error = %v", err)
+				}
+				ids := make([]int, len(got))
+				for i, entry := range got {
+					ids[i] = entry.ID
+				}
+				if len(ids) != len(tt.wantIDs) {
+					t.Fatalf("List() IDs = %v, want %v", ids, tt.wantIDs)
+				}
+				for i := range ids {
+					if ids[i] != tt.wantIDs[i] {
+						t.Errorf("List() IDs = %v, want %v", ids, tt.wantIDs)
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		entry, err := history.Get(1)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if entry.Seed == nil || *entry.Seed != seed {
+			t.Errorf("Get() seed = %v, want %v", entry.Seed, seed)
+		}
+		if _, err := history.Get(99); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("Get() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := history.Delete(1, 99); !errors.Is(err, store.ErrNotFound) {
+			t.Errorf("Delete() error = %v, want ErrNotFound", err)
+		}
+		if err := history.Delete(1, 2); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		got, _ := history.List(store.HistoryFilter{})
+		if len(got) != 1 || got[0].ID != 3 {
+			t.Errorf("List() after Delete() = %v, want only entry 3", got)
+		}
+	})
+}
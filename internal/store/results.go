@@ -0,0 +1,238 @@
+package store
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/kawana77b/loto/internal/util"
+)
+
+// DrawResult holds the winning numbers of a past draw.
+type DrawResult struct {
+	Number  int   `json:"number"`          // Draw number (回号)
+	Date    Date  `json:"date"`            // Draw date
+	Numbers []int `json:"numbers"`         // Main numbers
+	Bonus   []int `json:"bonus,omitempty"` // Bonus numbers
+}
+
+// Draw returns the winning numbers as a loto.Draw.
+func (r DrawResult) Draw() loto.Draw {
+	bonus := r.Bonus
+	if bonus == nil {
+		bonus = []int{}
+	}
+	return loto.Draw{
+		Numbers: r.Numbers,
+		Bonus:   bonus,
+	}
+}
+
+// ResultStore stores the draw results of each lottery type in a JSON Lines file.
+type ResultStore struct {
+	dir string
+}
+
+// NewResultStore creates a result store that keeps its files in the given directory.
+func NewResultStore(dir string) *ResultStore {
+	return &ResultStore{
+		dir: dir,
+	}
+}
+
+// DefaultResultStore returns the result store under the data directory.
+func DefaultResultStore() (*ResultStore, error) {
+	dir, err := DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewResultStore(filepath.Join(dir, "results")), nil
+}
+
+// path returns the file path of the results of the lottery type.
+func (s *ResultStore) path(t loto.LotteryType) string {
+	return filepath.Join(s.dir, t.String()+".jsonl")
+}
+
+// Load returns the stored results of the lottery type sorted by draw number.
+func (s *ResultStore) Load(t loto.LotteryType) ([]DrawResult, error) {
+	results, err := readJSONL[DrawResult](s.path(t))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(results, func(a, b DrawResult) int {
+		return a.Number - b.Number
+	})
+	return results, nil
+}
+
+// Save replaces the stored results of the lottery type.
+func (s *ResultStore) Save(t loto.LotteryType, results []DrawResult) error {
+	slices.SortFunc(results, func(a, b DrawResult) int {
+		return a.Number - b.Number
+	})
+	return writeJSONL(s.path(t), results)
+}
+
+// ImportSummary reports what an import changed.
+type ImportSummary struct {
+	Added   int // Number of draws that were not stored yet
+	Updated int // Number of stored draws that were replaced
+}
+
+// Import reads draw results from CSV and merges them into the stored results of the lottery type.
+// Stored draws with the same draw number are replaced. Nothing is stored if any row is invalid.
+func (s *ResultStore) Import(t loto.LotteryType, r io.Reader) (ImportSummary, error) {
+	imported, err := ParseResultsCSV(t, r)
+	if err != nil {
+		return ImportSummary{}, err
+	}
+
+	stored, err := s.Load(t)
+	if err != nil {
+		return ImportSummary{}, err
+	}
+	byNumber := make(map[int]int, len(stored))
+	for i, result := range stored {
+		byNumber[result.Number] = i
+	}
+
+	var summary ImportSummary
+	for _, result := range imported {
+		if i, ok := byNumber[result.Number]; ok {
+			stored[i] = result
+			summary.Updated++
+		} else {
+			byNumber[result.Number] = len(stored)
+			stored = append(stored, result)
+			summary.Added++
+		}
+	}
+	return summary, s.Save(t, stored)
+}
+
+// ParseResultsCSV parses draw results of the lottery type from CSV and validates every row
+// against the configuration of the lottery type.
+//
+// Each row is "draw number, date, main numbers..., bonus numbers...".
+// The digits of numbers games may also be given in a single column (e.g., "033").
+// A header row, whose fields are all labels, is skipped, and draw numbers may be written as "第1850回".
+func ParseResultsCSV(t loto.LotteryType, r io.Reader) ([]DrawResult, error) {
+	config, ok := loto.Lookup(t)
+	if !ok {
+		return nil, fmt.Errorf("invalid lottery type: %s", t)
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	results := []DrawResult{}
+	seen := make(map[int]int)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if line == 1 && isHeader(record) {
+			continue
+		}
+
+		result, err := parseResultRecord(config, record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if prev, ok := seen[result.Number]; ok {
+			return nil, fmt.Errorf("line %d: draw %d is already given on line %d", line, result.Number, prev)
+		}
+		seen[result.Number] = line
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// isHeader reports whether the record is a header row: none of its fields is a draw number, a date or a number.
+// A first row with a malformed draw number but a date or numbers is a draw result, so its error is reported.
+func isHeader(record []string) bool {
+	if len(record) == 0 {
+		return false
+	}
+	for _, field := range record {
+		field = strings.TrimSpace(field)
+		if _, err := parseDrawNumber(field); err == nil {
+			return false
+		}
+		if _, err := ParseDate(field); err == nil {
+			return false
+		}
+		if _, err := strconv.Atoi(field); err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDrawNumber parses a draw number written as "1850" or "第1850回".
+func parseDrawNumber(s string) (int, error) {
+	s = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(s), "第"), "回")
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid draw number: %q", s)
+	}
+	return n, nil
+}
+
+// parseResultRecord parses and validates a CSV record of a draw result.
+func parseResultRecord(config loto.LotteryConfig, record []string) (DrawResult, error) {
+	if len(record) < 3 {
+		return DrawResult{}, fmt.Errorf("expected draw number, date and numbers, got %d columns", len(record))
+	}
+	number, err := parseDrawNumber(record[0])
+	if err != nil {
+		return DrawResult{}, err
+	}
+	date, err := ParseDate(strings.TrimSpace(record[1]))
+	if err != nil {
+		return DrawResult{}, err
+	}
+
+	var values []int
+	if fields := record[2:]; len(fields) == 1 && config.Category == loto.NUMBERS {
+		values, err = util.ParseDigits(fields[0])
+	} else {
+		values, err = util.ParseInts(strings.Join(fields, ","))
+	}
+	if err != nil {
+		return DrawResult{}, err
+	}
+	if len(values) != config.Count+config.Bonus {
+		return DrawResult{}, fmt.Errorf("expected %d numbers and %d bonus numbers, got %d values", config.Count, config.Bonus, len(values))
+	}
+
+	result := DrawResult{
+		Number:  number,
+		Date:    date,
+		Numbers: values[:config.Count],
+	}
+	if config.Bonus > 0 {
+		result.Bonus = values[config.Count:]
+	}
+	if err := config.ValidateDraw(result.Draw()); err != nil {
+		return DrawResult{}, err
+	}
+	if !config.AllowDuplicate {
+		// Loto: store the numbers in ascending order
+		slices.Sort(result.Numbers)
+		slices.Sort(result.Bonus)
+	}
+	return result, nil
+}
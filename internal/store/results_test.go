@@ -0,0 +1,167 @@
+package store_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kawana77b/loto/internal/loto"
+	"github.com/kawana77b/loto/internal/store"
+)
+
+// TestParseResultsCSV tests the ParseResultsCSV function
+func TestParseResultsCSV(t *testing.T) {
+	tests := []struct {
+		name        string
+		lotteryType loto.LotteryType
+		csv         string
+		want        []store.DrawResult
+		wantErr     bool
+	}{
+		{
+			name:        "loto6 with header and bonus",
+			lotteryType: loto.LOTO_6,
+			csv:         "draw,date,n1,n2,n3,n4,n5,n6,bonus\n第1850回,2023/12/4,41,12,15,20,33,3,7\n",
+			want: []store.DrawResult{
+				{Number: 1850, Date: mustDate(t, "2023-12-04"), Numbers: []int{3, 12, 15, 20, 33, 41}, Bonus: []int{7}},
+			},
+		},
+		{
+			name:        "numbers3 digits in a single column",
+			lotteryType: loto.NUMBERS_3,
+			csv:         "6400,2024-01-04,033\n",
+			want: []store.DrawResult{
+				{Number: 6400, Date: mustDate(t, "2024-01-04"), Numbers: []int{0, 3, 3}},
+			},
+		},
+		{
+			name:        "numbers4 digits in separate columns",
+			lotteryType: loto.NUMBERS_4,
+			csv:         "6400,2024-01-04,1,2,3,4\n",
+			want: []store.DrawResult{
+				{Number: 6400, Date: mustDate(t, "2024-01-04"), Numbers: []int{1, 2, 3, 4}},
+			},
+		},
+		{
+			name:        "out of range",
+			lotteryType: loto.LOTO_6,
+			csv:         "1,2024-01-04,1,2,3,4,5,44,7\n",
+			wantErr:     true,
+		},
+		{
+			name:        "missing bonus",
+			lotteryType: loto.LOTO_7,
+			csv:         "1,2024-01-04,1,2,3,4,5,6,7,8\n",
+			wantErr:     true,
+		},
+		{
+			name:        "bonus is a main number",
+			lotteryType: loto.LOTO_MINI,
+			csv:         "1,2024-01-04,1,2,3,4,5,5\n",
+			wantErr:     true,
+		},
+		{
+			name:        "invalid date",
+			lotteryType: loto.NUMBERS_3,
+			csv:         "1,yesterday,123\n",
+			wantErr:     true,
+		},
+		{
+			name:        "malformed draw number on the first line",
+			lotteryType: loto.LOTO_6,
+			csv:         "18a0,2024-01-04,1,2,3,4,5,6,7\n1851,2024-01-08,1,2,3,4,5,6,7\n",
+			wantErr:     true,
+		},
+		{
+			name:        "japanese header",
+			lotteryType: loto.NUMBERS_3,
+			csv:         "回号,抽せん日,当せん番号\n6400,2024-01-04,033\n",
+			want: []store.DrawResult{
+				{Number: 6400, Date: mustDate(t, "2024-01-04"), Numbers: []int{0, 3, 3}},
+			},
+		},
+		{
+			name:        "duplicated draw number",
+			lotteryType: loto.NUMBERS_3,
+			csv:         "1,2024-01-04,123\n1,2024-01-05,456\n",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.ParseResultsCSV(tt.lotteryType, strings.NewReader(tt.csv))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseResultsCSV() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseResultsCSV() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestResultStore_Import tests that imports are merged by draw number
+func TestResultStore_Import(t *testing.T) {
+	results := store.NewResultStore(t.TempDir())
+
+	summary, err := results.Import(loto.NUMBERS_3, strings.NewReader("2,2024-01-05,456\n1,2024-01-04,123\n"))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if summary.Added != 2 || summary.Updated != 0 {
+		t.Errorf("Import() = %+v, want 2 added", summary)
+	}
+
+	summary, err = results.Import(loto.NUMBERS_3, strings.NewReader("2,2024-01-05,789\n3,2024-01-08,000\n"))
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if summary.Added != 1 || summary.Updated != 1 {
+		t.Errorf("Import() = %+v, want 1 added and 1 updated", summary)
+	}
+
+	stored, err := results.Load(loto.NUMBERS_3)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	numbers := make([]int, len(stored))
+	for i, result := range stored {
+		numbers[i] = result.Number
+	}
+	if !reflect.DeepEqual(numbers, []int{1, 2, 3}) {
+		t.Errorf("Load() draw numbers = %v, want [1 2 3]", numbers)
+	}
+	if !reflect.DeepEqual(stored[1].Numbers, []int{7, 8, 9}) {
+		t.Errorf("Load() draw 2 = %v, want [7 8 9]", stored[1].Numbers)
+	}
+
+	// An invalid file stores nothing
+	if _, err := results.Import(loto.NUMBERS_3, strings.NewReader("4,2024-01-09,111\n5,2024-01-10,12\n")); err == nil {
+		t.Fatal("Import() error = nil, want error")
+	}
+	if stored, _ := results.Load(loto.NUMBERS_3); len(stored) != 3 {
+		t.Errorf("Load() after failed import length = %v, want 3", len(stored))
+	}
+}
+
+// TestDataDir tests that the data directory follows XDG_DATA_HOME
+func TestDataDir(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/tmp/xdg")
+	dir, err := store.DataDir()
+	if err != nil {
+		t.Fatalf("DataDir() error = %v", err)
+	}
+	if dir != "/tmp/xdg/loto" {
+		t.Errorf("DataDir() = %v, want /tmp/xdg/loto", dir)
+	}
+}
+
+func mustDate(t *testing.T, s string) store.Date {
+	t.Helper()
+	d, err := store.ParseDate(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
@@ -0,0 +1,148 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// appName is the name of the directory loto stores its data in.
+const appName = "loto"
+
+// DataDir returns the directory where loto stores its data.
+// It is $XDG_DATA_HOME/loto, or ~/.local/share/loto if XDG_DATA_HOME is not set.
+func DataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, appName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the data directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", appName), nil
+}
+
+// ConfigDir returns the directory where loto reads its configuration from.
+// It is $XDG_CONFIG_HOME/loto, or ~/.config/loto if XDG_CONFIG_HOME is not set.
+func ConfigDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, appName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the config directory: %w", err)
+	}
+	return filepath.Join(home, ".config", appName), nil
+}
+
+// Date is a calendar date that is stored as "2006-01-02".
+type Date struct {
+	time.Time
+}
+
+// dateLayout is the layout of a Date in storage.
+const dateLayout = "2006-01-02"
+
+// NewDate returns the date of the given time.
+func NewDate(t time.Time) Date {
+	return Date{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// ParseDate parses a date written as "2006-01-02", "2006/01/02" or "2006/1/2".
+func ParseDate(s string) (Date, error) {
+	for _, layout := range []string{dateLayout, "2006/01/02", "2006/1/2"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return NewDate(t), nil
+		}
+	}
+	return Date{}, fmt.Errorf("invalid date: %q", s)
+}
+
+// String returns the date formatted as "2006-01-02".
+func (d Date) String() string {
+	return d.Format(dateLayout)
+}
+
+// MarshalJSON encodes the date as "2006-01-02".
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a date written as "2006-01-02".
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// readJSONL reads every record of a JSON Lines file.
+// A missing file is treated as empty.
+func readJSONL[T any](path string) ([]T, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []T{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records := []T{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record T
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// writeJSONL replaces a JSON Lines file with the given records.
+// The file is written to a temporary file first so that a failed write never corrupts it.
+func writeJSONL[T any](path string, records []T) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
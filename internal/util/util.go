@@ -1,7 +1,12 @@
 package util
 
 import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"fmt"
 	"math/rand/v2"
+	"strconv"
+	"strings"
 )
 
 // Number is a constraint that matches all numeric types.
@@ -17,22 +22,188 @@ func Abs[T Number](n T) T {
 	return n
 }
 
+// ParseInts parses a comma-separated list of integers (e.g., "1,7,38").
+func ParseInts(s string) ([]int, error) {
+	fields := strings.Split(s, ",")
+	results := make([]int, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number: %q", field)
+		}
+		results = append(results, n)
+	}
+	return results, nil
+}
+
+// ParseRange parses an inclusive range of integers such as "100-160", or a single integer such as "3".
+func ParseRange(s string) (min, max int, err error) {
+	lower, upper, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		upper = lower
+	}
+	min, err = strconv.Atoi(strings.TrimSpace(lower))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid range: %q", s)
+	}
+	max, err = strconv.Atoi(strings.TrimSpace(upper))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid range: %q", s)
+	}
+	if min > max {
+		return 0, 0, fmt.Errorf("invalid range: %q. The lower bound is greater than the upper bound", s)
+	}
+	return min, max, nil
+}
+
+// ParseDigits parses a string of decimal digits into its digits (e.g., "033" -> [0, 3, 3]).
+func ParseDigits(s string) ([]int, error) {
+	s = strings.TrimSpace(s)
+	results := make([]int, 0, len(s))
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return nil, fmt.Errorf("invalid digits: %q", s)
+		}
+		results = append(results, int(r-'0'))
+	}
+	return results, nil
+}
+
+// NewSeededSource returns a deterministic random source initialized with the given seed.
+func NewSeededSource(seed uint64) rand.Source {
+	return rand.NewPCG(seed, seed)
+}
+
+// CryptoSource is a random source backed by crypto/rand.
+// Its values cannot be predicted, so it cannot be seeded either.
+type CryptoSource struct{}
+
+// Uint64 returns a cryptographically secure random uint64.
+func (CryptoSource) Uint64() uint64 {
+	var b [8]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// globalSource draws from the global math/rand/v2 state.
+type globalSource struct{}
+
+// Uint64 returns a random uint64 from the global random source.
+func (globalSource) Uint64() uint64 {
+	return rand.Uint64()
+}
+
+// IntN returns a uniformly distributed random integer in [0, n) drawn from src.
+// Values that would bias the result towards small integers are rejected and redrawn.
+// If src is nil, the global random source is used. It panics if n <= 0.
+func IntN(src rand.Source, n int) int {
+	if n <= 0 {
+		panic("util: invalid argument to IntN")
+	}
+	if src == nil {
+		src = globalSource{}
+	}
+	bound := uint64(n)
+	// threshold is 2^64 mod bound; values below it make the final modulo uneven.
+	threshold := -bound % bound
+	for {
+		v := src.Uint64()
+		if v >= threshold {
+			return int(v % bound)
+		}
+	}
+}
+
+// Uint64 returns a random uint64 drawn from src.
+// If src is nil, the global random source is used.
+func Uint64(src rand.Source) uint64 {
+	if src == nil {
+		src = globalSource{}
+	}
+	return src.Uint64()
+}
+
+// Float64 returns a uniformly distributed random float64 in [0, 1) drawn from src.
+// If src is nil, the global random source is used.
+func Float64(src rand.Source) float64 {
+	if src == nil {
+		src = globalSource{}
+	}
+	// 53 random bits fill the mantissa exactly
+	return float64(src.Uint64()>>11) / (1 << 53)
+}
+
+// WeightedIndex returns a random index of weights, each index chosen with a probability proportional to its weight.
+// The weights must be non-negative with a positive total. If src is nil, the global random source is used.
+func WeightedIndex(src rand.Source, weights []float64) int {
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	r := Float64(src) * total
+	for i, w := range weights {
+		if r < w {
+			return i
+		}
+		r -= w
+	}
+	// Rounding errors may leave r just above the last weight
+	for i := len(weights) - 1; i > 0; i-- {
+		if weights[i] > 0 {
+			return i
+		}
+	}
+	return 0
+}
+
 // Shuffle randomly shuffles the elements of the input slice and returns a new slice with the shuffled elements.
-func Shuffle[T any](s []T) []T {
+// If src is nil, the global random source is used.
+func Shuffle[T any](src rand.Source, s []T) []T {
 	results := make([]T, len(s))
 	copy(results, s)
-	rand.Shuffle(len(results), func(i, j int) {
+	// Fisher-Yates shuffle
+	for i := len(results) - 1; i > 0; i-- {
+		j := IntN(src, i+1)
 		results[i], results[j] = results[j], results[i]
-	})
+	}
 	return results
 }
 
+// Sample returns k distinct random integers in [0, n), the first k of a random permutation,
+// without going through every integer (a partial Fisher-Yates shuffle that keeps only the swapped integers).
+// The integers are drawn one after another, so the sample of a smaller k is a prefix of the sample of a larger one
+// from the same source. If src is nil, the global random source is used. It panics if k is not between 0 and n.
+func Sample(src rand.Source, n, k int) []int {
+	if k < 0 || k > n {
+		panic("util: invalid argument to Sample")
+	}
+	swapped := make(map[int]int, k)
+	at := func(i int) int {
+		if v, ok := swapped[i]; ok {
+			return v
+		}
+		return i
+	}
+	result := make([]int, k)
+	for i := range k {
+		j := i + IntN(src, n-i)
+		result[i], swapped[j] = at(j), at(i)
+	}
+	return result
+}
+
 // RandomPick randomly selects and returns a single element from the input slice.
-func RandomPick[T any](s []T) (T, bool) {
+// If src is nil, the global random source is used.
+func RandomPick[T any](src rand.Source, s []T) (T, bool) {
 	if len(s) == 0 {
 		var zero T
 		return zero, false
 	}
-	idx := rand.IntN(len(s))
-	return s[idx], true
+	return s[IntN(src, len(s))], true
 }